@@ -0,0 +1,21 @@
+//skillcheck validates combat.SkillTreesDB, run it after editing skill trees
+//	go run ./cmd/skillcheck
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/steelx/go-rpg-cgm/combat"
+)
+
+func main() {
+	errs := combat.ValidateSkillTrees(combat.SkillTreesDB)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+	fmt.Printf("%d skill trees ok\n", len(combat.SkillTreesDB))
+}
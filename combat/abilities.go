@@ -0,0 +1,144 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//Teachables abilities taught by equipped items, each ability once
+func (a Actor) Teachables() []world.Teachable {
+	var list []world.Teachable
+	seen := make(map[string]bool)
+	for _, slot := range a.EquipSlots {
+		for _, t := range world.ItemsDB[a.Equipped[slot.Id]].Teaches {
+			if !seen[t.Id] {
+				seen[t.Id] = true
+				list = append(list, t)
+			}
+		}
+	}
+	return list
+}
+
+func (a Actor) IsMastered(t world.Teachable) bool {
+	return a.AP[t.Id] >= t.AP
+}
+
+//Ability a Magic, Special or Passive an Actor can know
+type Ability struct {
+	Action string //ActionMagic, ActionSpecial or ActionPassive
+	Id     string //SpellsDB, SpecialsDB or PassivesDB key
+}
+
+//Name display name e.g. "Heal"
+func (ab Ability) Name() string {
+	switch ab.Action {
+	case ActionMagic:
+		return world.SpellsDB[ab.Id].Name
+	case ActionSpecial:
+		return world.SpecialsDB[ab.Id].Name
+	}
+	return world.PassivesDB[ab.Id].Name
+}
+
+//AbilityName display name of a Teachable e.g. "Heal"
+func AbilityName(t world.Teachable) string {
+	return Ability{Action: t.Action, Id: t.Id}.Name()
+}
+
+//knows tells if Magic or Special list already has the ability
+func (a Actor) knows(ab Ability) bool {
+	if ab.Action == ActionMagic {
+		return hasString(a.Magic, ab.Id)
+	}
+	return hasString(a.Special, ab.Id)
+}
+
+//grant unlocks the menu action and adds ability unless known, granted map
+//remembers it so it can be taken back, as well as the menu action if it had to be unlocked.
+//A known ability may still be locked e.g. the hero's Slash before level 5,
+//nil grantedActions leaves the menu action as it is
+func (a *Actor) grant(ab Ability, granted map[string]string, grantedActions *[]string) {
+	if ab.Action == ActionPassive {
+		return
+	}
+	if grantedActions != nil && !a.HasAction(ab.Action) {
+		a.Actions = append(a.Actions, ab.Action)
+		*grantedActions = append(*grantedActions, ab.Action)
+	}
+	if a.knows(ab) {
+		return
+	}
+	t := &a.Special
+	if ab.Action == ActionMagic {
+		t = &a.Magic
+	}
+	*t = append(*t, ab.Id)
+	granted[ab.Id] = ab.Action
+}
+
+//takeBack removes everything grant added
+func (a *Actor) takeBack(granted map[string]string, grantedActions []string) {
+	for id, action := range granted {
+		if action == ActionMagic {
+			a.Magic = removeString(a.Magic, id)
+		} else {
+			a.Special = removeString(a.Special, id)
+		}
+	}
+	for _, action := range grantedActions {
+		a.Actions = removeString(a.Actions, action)
+	}
+}
+
+//RefreshLentAbilities grants Magic & Special taught by equipped items until
+//they are mastered, and takes back the ones no longer taught.
+//Taught passives don't need this, see ActivePassives
+func (a *Actor) RefreshLentAbilities() {
+	a.takeBack(a.LentAbilities, a.lentActions)
+	a.LentAbilities = make(map[string]string)
+	a.lentActions = nil
+
+	for _, t := range a.Teachables() {
+		if !a.IsMastered(t) {
+			a.grant(Ability{Action: t.Action, Id: t.Id}, a.LentAbilities, &a.lentActions)
+		}
+	}
+}
+
+//AddAP earned in combat goes to every ability taught by equipped items,
+//returns the ones mastered by it
+func (a *Actor) AddAP(ap float64) []world.Teachable {
+	var mastered []world.Teachable
+	for _, t := range a.Teachables() {
+		if a.IsMastered(t) {
+			continue
+		}
+		a.AP[t.Id] = math.Min(t.AP, a.AP[t.Id]+ap)
+		if a.IsMastered(t) {
+			a.master(t)
+			mastered = append(mastered, t)
+		}
+	}
+	return mastered
+}
+
+//master keeps the ability after the item is taken off
+func (a *Actor) master(t world.Teachable) {
+	if t.Action == ActionPassive {
+		a.LearnPassives([]string{t.Id})
+		return
+	}
+	a.UnlockMenuAction(t.Action)
+	a.AddAction(t.Action, []string{t.Id})
+}
+
+func removeString(list []string, s string) []string {
+	for i, v := range list {
+		if v == s {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list
+}
@@ -0,0 +1,66 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestEquipmentLendsAbilities(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(6, 1) //Dragon's Cloak teaches Heal
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+
+	hero.Equip("Armor", world.ItemsDB[6])
+	if !hasString(hero.Magic, world.SpellHeal) || !hero.HasAction(ActionMagic) {
+		t.Fatalf("Dragon's Cloak should lend Heal, got %v %v", hero.Magic, hero.Actions)
+	}
+
+	hero.UnEquip("Armor")
+	if hasString(hero.Magic, world.SpellHeal) || hero.HasAction(ActionMagic) {
+		t.Errorf("Heal should be gone with the cloak, got %v %v", hero.Magic, hero.Actions)
+	}
+}
+
+func TestAPMastersAbility(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(6, 1)
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+	hero.Equip("Armor", world.ItemsDB[6])
+
+	if mastered := hero.AddAP(20); len(mastered) != 0 {
+		t.Fatalf("20 of 30 AP should not master Heal")
+	}
+	mastered := hero.AddAP(20)
+	if len(mastered) != 1 || mastered[0].Id != world.SpellHeal {
+		t.Fatalf("expected Heal mastered, got %v", mastered)
+	}
+	if hero.AP[world.SpellHeal] != 30 {
+		t.Errorf("AP should stop at 30, got %v", hero.AP[world.SpellHeal])
+	}
+
+	hero.UnEquip("Armor")
+	if !hasString(hero.Magic, world.SpellHeal) || !hero.HasAction(ActionMagic) {
+		t.Errorf("mastered Heal must stay after unequip, got %v %v", hero.Magic, hero.Actions)
+	}
+}
+
+func TestTaughtPassiveIsActiveWhileEquipped(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(28, 1) //Serpent Band teaches ImmunePoison
+	w.Party.Add(ActorFromDef(ThiefDef))
+	thief := w.Party.Members["thief"]
+
+	thief.Equip("Accessory1", world.ItemsDB[28])
+	if !thief.IsImmune(world.StatusPoison) {
+		t.Fatalf("Serpent Band should protect from poison while worn")
+	}
+
+	thief.AddAP(40)
+	thief.UnEquip("Accessory1")
+	if !thief.IsImmune(world.StatusPoison) {
+		t.Errorf("mastered Poison Ward must stay after unequip")
+	}
+}
@@ -2,6 +2,7 @@ package combat
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 
 	"github.com/faiface/pixel"
@@ -13,6 +14,7 @@ import (
 
 type ActorDropItem struct {
 	XP     float64
+	AP     float64
 	Gold   float64
 	Always []int //ActionItem ids that are guaranteed to drop
 	Chance *OddmentTable
@@ -23,21 +25,45 @@ type ActorDropItem struct {
 type Actor struct {
 	Id, Name   string
 	Stats      world.Stats
-	StatGrowth map[string]func() int
-
-	PortraitTexture  pixel.Picture
-	Portrait         *pixel.Sprite
-	Level            int
-	XP, NextLevelXP  float64
-	Actions          []string
-	Magic            []string
-	Special          []string
-	StealItem        int //Item ID only for Enemy actors
-	ActiveEquipSlots []int
-	Equipped         map[string]int //int is ItemsDB Id
-	worldRef         *WorldExtended
-	isPlayer         bool
-	Drop             ActorDropItem
+	StatGrowth map[string]func() int //ActorDef's, see JobStatGrowth
+
+	PortraitTexture pixel.Picture
+	Portrait        *pixel.Sprite
+	Level           int
+	XP, NextLevelXP float64
+	XPCurve         XPCurve
+	Actions         []string
+	Magic           []string
+	Special         []string
+	Passives        []string //learned world.PassivesDB ids, see ActivePassives
+	StealItem       int      //Item ID only for Enemy actors
+	EquipSlots      []EquipSlot
+	Equipped        map[string]int     //EquipSlot.Id -> ItemsDB Id
+	Statuses        map[string]bool    //e.g. world.StatusPoison
+	AP              map[string]float64 //ability id -> AP earned, see world.Teachable
+	LentAbilities   map[string]string  //ability id -> menu action, granted by equipment until mastered
+	lentActions     []string           //menu actions unlocked only by equipment
+	Job             string             //JobsDB key
+	HomeJob         string             //ActorDef.Job, the only job NativeAbilities and NativeActions apply in
+	NativeAbilities []Ability          //ActorDef Magic & Special plus ActionGrowth
+	NativeActions   []string           //menu actions unlocked by ActionGrowth
+	JobLevels       map[string]int
+	JobXP           map[string]float64
+	AbilitySlots    []Ability         //learned in other jobs, see MaxAbilitySlots
+	JobGranted      map[string]string //ability id -> menu action, granted by Job or AbilitySlots
+	jobActions      []string          //menu actions unlocked only by Job
+	SkillPoints     int
+	Skills          []string            //unlocked SkillNode ids, see SkillTreesDB
+	SkillGranted    map[string]string   //ability id -> menu action, granted by Skills
+	skillActions    []string            //menu actions unlocked only by Skills
+	Row             Row                 //kept between battles, see EffectiveRow
+	Limit           string              //world.LimitsDB key, "" if none
+	LimitGauge      float64             //kept between battles, see LimitReady
+	Affinities      map[string]Affinity //element -> Affinity, missing elements are AffinityNormal
+	Inflicts        map[string]float64  //status -> base chance melee attacks inflict it, see InflictStatuses
+	worldRef        *WorldExtended
+	isPlayer        bool
+	Drop            ActorDropItem
 }
 
 // ActorCreate
@@ -49,42 +75,78 @@ func ActorCreate(def ActorDef, randName ...interface{}) Actor {
 	actorAvatar, err := utilz.LoadPicture(def.Portrait)
 	utilz.PanicIfErr(err)
 
+	a := ActorFromDef(def)
+	a.Name = fmt.Sprintf("%s%s", def.Name, randNameV)
+	a.PortraitTexture = actorAvatar
+	a.Portrait = pixel.NewSprite(actorAvatar, actorAvatar.Bounds())
+	return a
+}
+
+//ActorFromDef everything ActorCreate does but loading the portrait,
+//for code that runs without the game's resources e.g. tests
+func ActorFromDef(def ActorDef) Actor {
 	a := Actor{
-		Id:               def.Id,
-		isPlayer:         def.IsPlayer,
-		Name:             fmt.Sprintf("%s%s", def.Name, randNameV),
-		StatGrowth:       def.StatGrowth,
-		Stats:            world.StatsCreate(def.Stats),
-		XP:               0,
-		Level:            def.Level,
-		PortraitTexture:  actorAvatar,
-		Portrait:         pixel.NewSprite(actorAvatar, actorAvatar.Bounds()),
-		Actions:          def.Actions,
-		Magic:            def.Magic,
-		Special:          def.Special,
-		StealItem:        def.StealItem,
-		ActiveEquipSlots: def.ActiveEquipSlots,
-		Equipped: map[string]int{
-			ActorLabels.EquipSlotId[0]: def.Weapon,
-			ActorLabels.EquipSlotId[1]: def.Armor,
-			ActorLabels.EquipSlotId[2]: def.Access1,
-			ActorLabels.EquipSlotId[3]: def.Access2,
-		},
+		Id:            def.Id,
+		isPlayer:      def.IsPlayer,
+		Name:          def.Name,
+		StatGrowth:    def.StatGrowth,
+		Stats:         world.StatsCreate(def.Stats),
+		XP:            0,
+		Level:         def.Level,
+		Actions:       append([]string(nil), def.Actions...),
+		StealItem:     def.StealItem,
+		EquipSlots:    def.EquipSlots,
+		Equipped:      make(map[string]int),
+		Statuses:      make(map[string]bool),
+		AP:            make(map[string]float64),
+		LentAbilities: make(map[string]string),
+		Job:           def.Job,
+		HomeJob:       def.Job,
+		JobLevels:     make(map[string]int),
+		JobXP:         make(map[string]float64),
+		JobGranted:    make(map[string]string),
+		SkillGranted:  make(map[string]string),
+		Row:           def.Row,
+		Limit:         def.Limit,
+		Affinities:    def.Affinities,
+		Inflicts:      def.Inflicts,
 	}
+	if def.Job != "" {
+		a.JobLevels[def.Job] = 1
+	}
+	a.LearnNative(ActionMagic, def.Magic)
+	a.LearnNative(ActionSpecial, def.Special)
+
+	if a.EquipSlots == nil {
+		a.EquipSlots = DefaultEquipSlots
+	}
+	for _, slot := range a.EquipSlots {
+		itemId := def.Equipment[slot.Id]
+		a.Equipped[slot.Id] = itemId
+		if itemId != 0 {
+			a.Stats.Apply(world.EquipSource(slot.Id), world.ItemsDB[itemId].Modifier())
+		}
+	}
+	a.RefreshJobAbilities()
 
 	if !def.IsPlayer {
 		gold := utilz.RandInt(def.Drop.Gold[0], def.Drop.Gold[1])
 		a.Drop.XP = def.Drop.XP
+		a.Drop.AP = def.Drop.AP
 		a.Drop.Gold = float64(gold)
 		a.Drop.Chance = OddmentTableCreate(def.Drop.Chance)
 	}
 
-	a.NextLevelXP = NextLevel(a.Level)
+	a.XPCurve = DefaultXPCurve
+	if def.XPCurve != nil {
+		a.XPCurve = *def.XPCurve
+	}
+	a.NextLevelXP = a.XPCurve.NextLevel(a.Level)
 	return a
 }
 
 func (a *Actor) RenderEquipment(args ...interface{}) {
-	//renderer pixel.Target, x, y float64, index int
+	//renderer pixel.Target, x, y float64, slot EquipSlot
 	rendererV := reflect.ValueOf(args[0])
 	renderer := rendererV.Interface().(pixel.Target)
 	xV := reflect.ValueOf(args[1])
@@ -92,13 +154,16 @@ func (a *Actor) RenderEquipment(args ...interface{}) {
 	yV := reflect.ValueOf(args[2])
 	y := yV.Interface().(float64)
 	itemV := reflect.ValueOf(args[3])
-	slot := itemV.Interface().(int)
+	slot := itemV.Interface().(EquipSlot)
 
-	label := ActorLabels.EquipSlotId[slot]
+	label := slot.Label
 
-	itemId := a.Equipped[label]
+	itemId := a.Equipped[slot.Id]
 	item := world.ItemsDB[itemId]
 	equipmentText := item.Name
+	if a.IsSlotBlocked(slot.Id) {
+		equipmentText = "(two-handed)"
+	}
 
 	basicAtlasAscii := text.NewAtlas(basicfont.Face7x13, text.ASCII)
 	pos := pixel.V(x, y)
@@ -108,7 +173,11 @@ func (a *Actor) RenderEquipment(args ...interface{}) {
 }
 
 func (a Actor) ReadyToLevelUp() bool {
-	return a.XP >= a.NextLevelXP
+	return !a.IsMaxLevel() && a.XP >= a.NextLevelXP
+}
+
+func (a Actor) IsMaxLevel() bool {
+	return a.XPCurve.IsMaxLevel(a.Level)
 }
 
 func (a *Actor) AddXP(xp float64) bool {
@@ -118,12 +187,13 @@ func (a *Actor) AddXP(xp float64) bool {
 
 func (a Actor) CreateLevelUp() LevelUp {
 	levelUp := LevelUp{
-		XP:        -a.NextLevelXP,
-		Level:     1,
-		BaseStats: make(map[string]float64),
+		XP:          -a.NextLevelXP,
+		Level:       1,
+		BaseStats:   make(map[string]float64),
+		SkillPoints: SkillPointsPerLevel,
 	}
 
-	for id, diceRoll := range a.StatGrowth {
+	for id, diceRoll := range a.JobStatGrowth() {
 		levelUp.BaseStats[id] = float64(diceRoll())
 	}
 
@@ -139,17 +209,21 @@ func (a Actor) CreateLevelUp() LevelUp {
 func (a *Actor) ApplyLevel(levelUp LevelUp) {
 	a.XP += levelUp.XP
 	a.Level += levelUp.Level
-	a.NextLevelXP = NextLevel(a.Level)
+	a.SkillPoints += levelUp.SkillPoints
+	a.NextLevelXP = a.XPCurve.NextLevel(a.Level)
 
 	for k, v := range levelUp.BaseStats {
-		a.Stats.Base[k] += v
+		a.Stats.Set(k, a.Stats.GetBaseStat(k)+v)
 	}
 
 	// Unlock any special abilities etc.
 	for action, specialPower := range levelUp.Actions {
-		a.UnlockMenuAction(action)
-		a.AddAction(action, specialPower)
+		if action != ActionPassive && !hasString(a.NativeActions, action) {
+			a.NativeActions = append(a.NativeActions, action)
+		}
+		a.LearnNative(action, specialPower)
 	}
+	a.RefreshJobAbilities()
 
 	//Restore HP and MP on level up
 	maxHP := a.Stats.Get("HpMax")
@@ -163,45 +237,79 @@ func (a Actor) GetEquipSlotIdByItemType(itemT world.ItemType) string {
 	return ActorLabels.EquipSlotTypes[itemT]
 }
 
-func (a Actor) GetItemTypeBySlotPos(slot int) world.ItemType {
-	switch slot {
-	case 0:
-		return world.Weapon
-	case 1:
-		return world.Armor
-	case 2:
-		return world.Accessory
-	case 3:
-		return world.Accessory
+//GetEquipSlot finds slot by EquipSlot.Id e.g. "Accessory2"
+func (a Actor) GetEquipSlot(equipSlotId string) (EquipSlot, bool) {
+	for _, v := range a.EquipSlots {
+		if v.Id == equipSlotId {
+			return v, true
+		}
+	}
+	return EquipSlot{}, false
+}
+
+//IsSlotBlocked tells if a TwoHanded item equipped elsewhere locks equipSlotId
+func (a Actor) IsSlotBlocked(equipSlotId string) bool {
+	for _, v := range a.EquipSlots {
+		if v.Blocks == equipSlotId && world.ItemsDB[a.Equipped[v.Id]].TwoHanded {
+			return true
+		}
 	}
+	return false
+}
+
+//FitsSlot checks slot ItemTypes, Item.Restrictions and Jobs, ignores blocking
+func (a Actor) FitsSlot(slot EquipSlot, item world.Item) bool {
+	return slot.Allows(item.ItemType) && a.CanUse(item)
+}
 
-	return 0
+//CanEquip tells if item can go into equipSlotId right now
+func (a Actor) CanEquip(equipSlotId string, item world.Item) bool {
+	slot, ok := a.GetEquipSlot(equipSlotId)
+	return ok && !a.IsSlotBlocked(equipSlotId) && a.FitsSlot(slot, item)
 }
 
-func (a *Actor) Equip(equipSlotId string, item world.Item) {
+//Equip returns false if item doesn't fit equipSlotId, see CanEquip.
+//A TwoHanded item empties the slot it Blocks
+func (a *Actor) Equip(equipSlotId string, item world.Item) bool {
+	if item.Id != -1 && !a.CanEquip(equipSlotId, item) {
+		return false
+	}
+
 	prevItemId, ok := a.Equipped[equipSlotId]
 	if ok && prevItemId != 0 {
 		delete(a.Equipped, equipSlotId)
-		a.Stats.RemoveModifier(prevItemId)
+		a.Stats.RemoveSource(world.EquipSource(equipSlotId))
 		a.worldRef.AddItem(prevItemId, 1) //return back to World
 	}
 
 	//UnEquip
 	if item.Id == -1 {
-		return
+		a.RefreshLentAbilities()
+		return true
 	}
 
 	a.worldRef.RemoveItem(item.Id, 1) //remove from World
 	a.Equipped[equipSlotId] = item.Id
 
-	modifier := item.Stats
-	a.Stats.AddModifier(item.Id, modifier)
+	a.Stats.Apply(world.EquipSource(equipSlotId), item.Modifier())
+
+	slot, _ := a.GetEquipSlot(equipSlotId)
+	if item.TwoHanded && slot.Blocks != "" {
+		a.UnEquip(slot.Blocks)
+	}
+	a.RefreshLentAbilities()
+	return true
 }
 
 func (a *Actor) UnEquip(equipSlotId string) {
 	a.Equip(equipSlotId, world.Item{Id: -1})
 }
 
+//StatBreakdown base, equipment and buff parts of stat id
+func (a Actor) StatBreakdown(id world.StatId) world.StatBreakdown {
+	return a.Stats.Breakdown(id)
+}
+
 func (a Actor) CreateStatNameList() (statsIDs []string) {
 	for _, v := range ActorLabels.ActorStats {
 		statsIDs = append(statsIDs, v)
@@ -244,11 +352,12 @@ func (a Actor) PredictStats(equipSlotId string, item world.Item) map[string]floa
 	}
 
 	// Replace item
+	source := world.EquipSource(equipSlotId)
 	prevItemId, ok := a.Equipped[equipSlotId]
 	if ok {
-		a.Stats.RemoveModifier(prevItemId)
+		a.Stats.RemoveSource(source)
 	}
-	a.Stats.AddModifier(item.Id, item.Stats)
+	a.Stats.Apply(source, item.Modifier())
 
 	// Get values for modified stats
 	modifiedStats := make(map[string]float64)
@@ -262,16 +371,17 @@ func (a Actor) PredictStats(equipSlotId string, item world.Item) map[string]floa
 	}
 
 	// Undo replace item
-	a.Stats.RemoveModifier(item.Id)
-	if ok {
-		a.Stats.AddModifier(prevItemId, world.ItemsDB[prevItemId].Stats)
+	a.Stats.RemoveSource(source)
+	if ok && prevItemId != 0 {
+		a.Stats.Apply(source, world.ItemsDB[prevItemId].Modifier())
 	}
 
 	return diffStats
 }
 
+//CanUse item listing the Actor in Restrictions or its current Job in Jobs
 func (a Actor) CanUse(item world.Item) bool {
-	if len(item.Restrictions) == 0 {
+	if len(item.Restrictions) == 0 && len(item.Jobs) == 0 {
 		return true
 	}
 
@@ -280,6 +390,11 @@ func (a Actor) CanUse(item world.Item) bool {
 			return true
 		}
 	}
+	for _, v := range item.Jobs {
+		if v == a.Job {
+			return true
+		}
+	}
 
 	return false
 }
@@ -300,22 +415,108 @@ func (a *Actor) KO() {
 }
 
 func (a *Actor) UnlockMenuAction(actionId string) {
-	for _, v := range a.Actions {
-		if v == actionId {
-			//already exists
-			return
-		}
+	a.lentActions = removeString(a.lentActions, actionId) //now for good
+	a.jobActions = removeString(a.jobActions, actionId)
+	a.skillActions = removeString(a.skillActions, actionId)
+	if a.HasAction(actionId) {
+		return
 	}
 	a.Actions = append(a.Actions, actionId)
 }
 
+//HasAction tells if menu action e.g. ActionMagic is unlocked
+func (a Actor) HasAction(actionId string) bool {
+	return hasString(a.Actions, actionId)
+}
+
 func (a *Actor) AddAction(actionId string, specials []string) {
-	t := a.Special
+	t := &a.Special
 	if actionId == ActionMagic {
-		t = a.Magic
+		t = &a.Magic
 	}
 
 	for _, v := range specials {
-		t = append(t, v)
+		delete(a.LentAbilities, v) //now for good
+		delete(a.JobGranted, v)
+		delete(a.SkillGranted, v)
+		if !hasString(*t, v) {
+			*t = append(*t, v)
+		}
+	}
+}
+
+func hasString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+//RestoreHP heals a conscious Actor up to HpMax, returns HP actually restored
+func (a *Actor) RestoreHP(amount float64) float64 {
+	if a.IsKOed() {
+		return 0
+	}
+	hpNow := a.Stats.Get("HpNow")
+	hp := math.Min(a.Stats.Get("HpMax"), hpNow+amount)
+	a.Stats.Set("HpNow", hp)
+	return hp - hpNow
+}
+
+//RestoreMP refills MP of a conscious Actor up to MpMax, returns MP actually restored
+func (a *Actor) RestoreMP(amount float64) float64 {
+	if a.IsKOed() {
+		return 0
+	}
+	mpNow := a.Stats.Get("MpNow")
+	mp := math.Min(a.Stats.Get("MpMax"), mpNow+amount)
+	a.Stats.Set("MpNow", mp)
+	return mp - mpNow
+}
+
+//Revive brings back a KOed Actor with given HP, returns HP restored
+func (a *Actor) Revive(amount float64) float64 {
+	if !a.IsKOed() {
+		return 0
+	}
+	hp := math.Min(a.Stats.Get("HpMax"), amount)
+	a.Stats.Set("HpNow", hp)
+	return hp
+}
+
+//AddStatus returns false if a passive makes Actor immune
+func (a *Actor) AddStatus(status string) bool {
+	if a.IsImmune(status) {
+		return false
+	}
+	a.Statuses[status] = true
+	return true
+}
+
+func (a Actor) HasStatus(status string) bool {
+	return a.Statuses[status]
+}
+
+//HasAnyStatus tells if Actor suffers from any status ailment
+func (a Actor) HasAnyStatus() bool {
+	for _, v := range world.AllStatuses {
+		if a.Statuses[v] {
+			return true
+		}
+	}
+	return false
+}
+
+//CureStatus removes given statuses, returns false if Actor had none of them
+func (a *Actor) CureStatus(statuses []string) bool {
+	cured := false
+	for _, v := range statuses {
+		if a.Statuses[v] {
+			delete(a.Statuses, v)
+			cured = true
+		}
 	}
+	return cured
 }
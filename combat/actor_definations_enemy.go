@@ -23,8 +23,15 @@ var GoblinDef = ActorDef{
 	Name:     "Goblin",
 	Portrait: "../resources/avatar_hero.png", //temp we need this at Actor Create
 	Actions:  []string{ActionAttack},
+	Affinities: map[string]Affinity{
+		world.SpellFire: AffinityWeak,
+	},
+	Inflicts: map[string]float64{
+		world.StatusPoison: 0.25,
+	},
 	Drop: Drop{
 		XP:     150,
+		AP:     2,
 		Gold:   [2]int{5, 15},
 		Always: nil,
 		Chance: []DropChanceItem{
@@ -38,6 +45,7 @@ var GoblinDef = ActorDef{
 var DragonDef = ActorDef{
 	Id:       "dragon",
 	IsPlayer: false,
+	Row:      BackRow,
 	Stats: world.BaseStats{
 		HpNow:    200,
 		HpMax:    200,
@@ -49,8 +57,14 @@ var DragonDef = ActorDef{
 	Name:     "Green Dragon",
 	Portrait: "../resources/avatar_hero.png", //temp we need this at Actor Create
 	Actions:  []string{ActionAttack},
+	Affinities: map[string]Affinity{
+		world.SpellFire: AffinityAbsorb,
+		world.SpellIce:  AffinityWeak,
+		world.SpellBolt: AffinityReflect,
+	},
 	Drop: Drop{
 		XP:     350,
+		AP:     8,
 		Gold:   [2]int{250, 300},
 		Always: nil,
 		Chance: []DropChanceItem{
@@ -75,13 +89,19 @@ var OgreDef = ActorDef{
 	Name:     "Ogre",
 	Portrait: "../resources/avatar_hero.png", //temp we need this at Actor Create
 	Actions:  []string{ActionAttack},
+	Affinities: map[string]Affinity{
+		world.SpellIce:  AffinityResist,
+		world.SpellBolt: AffinityWeak,
+	},
 	Drop: Drop{
 		XP:     250,
+		AP:     5,
 		Gold:   [2]int{100, 200},
 		Always: nil,
 		Chance: []DropChanceItem{
 			{Oddment: 1, ItemId: -1},
 			{Oddment: 3, ItemId: 10},
+			{Oddment: 1, ItemId: 31},
 		},
 	},
 	StealItem: 12,
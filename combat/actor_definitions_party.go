@@ -11,6 +11,11 @@ const (
 	ActionMagic   = "Magic"
 	ActionSpecial = "Special"
 	ActionFlee    = "Flee"
+	ActionSwap    = "Swap"    //added in combat while the party has someone on the reserve bench
+	ActionRow     = "Row"     //added in combat, moves to the other row
+	ActionLimit   = "Limit"   //added in combat once the limit gauge is full
+	ActionTech    = "Tech"    //added in combat while partners are ready for a world.Tech
+	ActionPassive = "Passive" //ActionGrowth only, learned passives aren't menu actions
 )
 
 var PartyMembersDefinitions = map[string]ActorDef{
@@ -39,20 +44,26 @@ var HeroDef = ActorDef{
 		"Intelligence": world.StatsGrowth.Med,
 	},
 	ActionGrowth: map[int]map[string][]string{
+		3: {
+			ActionPassive: []string{world.PassiveCounterBoost},
+		},
 		5: {
 			ActionSpecial: []string{world.SpecialSlash},
 		},
 	},
-	Name:             "Chandragupta",
-	Portrait:         "../resources/avatar_hero.png",
-	Actions:          []string{ActionAttack, ActionItem, ActionFlee}, //removed ActionSpecial -> unlocks later
-	Special:          []string{world.SpecialSlash},
-	ActiveEquipSlots: []int{0, 1, 2, 3},
+	Name:       "Chandragupta",
+	Portrait:   "../resources/avatar_hero.png",
+	Actions:    []string{ActionAttack, ActionItem, ActionFlee}, //removed ActionSpecial -> unlocks later
+	Special:    []string{world.SpecialSlash},
+	EquipSlots: HeroEquipSlots,
+	Job:        JobWarrior,
+	Limit:      world.LimitBraveBlade,
 }
 
 var MageDef = ActorDef{
 	Id:       "mage",
 	IsPlayer: true,
+	Row:      BackRow,
 	Stats: world.BaseStats{
 		HpNow:    30,
 		HpMax:    30,
@@ -75,15 +86,24 @@ var MageDef = ActorDef{
 		2: {
 			ActionMagic: []string{world.SpellFire, world.SpellIce},
 		},
+		3: {
+			ActionMagic: []string{world.SpellLife},
+		},
 		4: {
 			ActionMagic: []string{world.SpellBurn},
 		},
+		5: {
+			ActionPassive: []string{world.PassiveMpSaver},
+		},
 	},
-	Name:             "Mrignayani",
-	Portrait:         "../resources/avatar_mage.png",
-	Actions:          []string{ActionAttack, ActionItem, ActionFlee}, //ActionMagic
-	Magic:            []string{world.SpellFire, world.SpellBurn, world.SpellBolt},
-	ActiveEquipSlots: []int{0, 1, 2, 3}, //mage if no attack slot, Access goes to Attack slot(fix pending)
+	Name:       "Mrignayani",
+	Portrait:   "../resources/avatar_mage.png",
+	Actions:    []string{ActionAttack, ActionItem, ActionFlee}, //ActionMagic
+	Magic:      []string{world.SpellFire, world.SpellBurn, world.SpellBolt, world.SpellHeal},
+	EquipSlots: MageEquipSlots,
+	XPCurve:    &XPCurve{Base: 1100, Exponent: 1.6, Quadratic: 0.8, Linear: 2, LevelCap: 99},
+	Job:        JobMage,
+	Limit:      world.LimitSanctuary,
 }
 
 var ThiefDef = ActorDef{
@@ -106,12 +126,21 @@ var ThiefDef = ActorDef{
 	},
 	ActionGrowth: map[int]map[string][]string{
 		2: {
-			world.SpecialSteal: []string{ActionSpecial},
+			ActionSpecial: []string{world.SpecialSteal},
+		},
+		4: {
+			ActionPassive: []string{world.PassiveStealBonus},
 		},
 	},
-	Name:             "Shashank",
-	Portrait:         "../resources/avatar_thief.png",
-	Actions:          []string{ActionAttack, ActionItem, ActionFlee},
-	Special:          []string{world.SpecialSteal},
-	ActiveEquipSlots: []int{0, 1, 2, 3},
+	Name:       "Shashank",
+	Portrait:   "../resources/avatar_thief.png",
+	Actions:    []string{ActionAttack, ActionItem, ActionFlee},
+	Special:    []string{world.SpecialSteal},
+	EquipSlots: ThiefEquipSlots,
+	XPCurve: &XPCurve{Table: []float64{
+		800, 2000, 3600, 5600, 8000, 11000, 14500, 18500, 23000, 28000,
+		33500, 39500, 46000, 53000, 60500, 68500, 77000, 86000, 95500, 105500,
+	}},
+	Job:   JobThief,
+	Limit: world.LimitShadowDance,
 }
@@ -3,8 +3,6 @@ package combat
 import "github.com/steelx/go-rpg-cgm/world"
 
 type ActorLabel struct {
-	EquipSlotLabels []string
-	EquipSlotId     []string
 	ActorStats      []string
 	ItemStats       []string
 	ActorStatLabels []string
@@ -17,18 +15,6 @@ type ActionLabels struct {
 }
 
 var ActorLabels = ActorLabel{
-	EquipSlotLabels: []string{
-		`Weapon `,
-		`Armor `,
-		`Accessory 1 `,
-		`Accessory 2 `,
-	},
-	EquipSlotId: []string{
-		"Weapon",
-		"Armor",
-		"Accessory1",
-		"Accessory2",
-	},
 	EquipSlotTypes: map[world.ItemType]string{
 		world.Weapon:    "Weapon",
 		world.Armor:     "Armor",
@@ -5,20 +5,26 @@ import (
 )
 
 type ActorDef struct {
-	Id               string //must match entityDef
-	Stats            world.BaseStats
-	StatGrowth       map[string]func() int
-	Level            int
-	ActionGrowth     map[int]map[string][]string //Level -> {Action : [special, special]}
-	Portrait         string
-	Name             string
-	Actions          []string
-	Magic            []string
-	Special          []string
-	StealItem        int //Item ID only for Enemy actors
-	ActiveEquipSlots []int
-	IsPlayer         bool
-	Equipment
+	Id           string //must match entityDef
+	Stats        world.BaseStats
+	StatGrowth   map[string]func() int
+	Level        int
+	ActionGrowth map[int]map[string][]string //Level -> {Action : [special, special]}
+	Portrait     string
+	Name         string
+	Actions      []string
+	Magic        []string
+	Special      []string
+	StealItem    int            //Item ID only for Enemy actors
+	EquipSlots   []EquipSlot    //nil means DefaultEquipSlots
+	Equipment    map[string]int //EquipSlot.Id -> ItemsDB.Id
+	IsPlayer     bool
+	Job          string   //JobsDB key, player actors only
+	XPCurve      *XPCurve //nil means DefaultXPCurve
+	Row          Row
+	Limit        string              //world.LimitsDB key, player actors only
+	Affinities   map[string]Affinity //element e.g. world.SpellFire -> Affinity
+	Inflicts     map[string]float64  //status e.g. world.StatusPoison -> chance of melee attacks
 	Drop
 }
 
@@ -29,22 +35,16 @@ type DropChanceItem struct {
 
 type Drop struct {
 	XP     float64
-	Gold   [2]int //range min, max
-	Always []int  //item ids that are guaranteed to drop
+	AP     float64 //ability points, see world.Teachable
+	Gold   [2]int  //range min, max
+	Always []int   //item ids that are guaranteed to drop
 	Chance []DropChanceItem
 }
 
 type LevelUp struct {
-	XP        float64
-	Level     int
-	BaseStats map[string]float64
-	Actions   map[string][]string
-}
-
-//Must match to ItemsDB ID
-type Equipment struct {
-	Weapon,
-	Armor,
-	Access1,
-	Access2 int //ItemsDB.Id
+	XP          float64
+	Level       int
+	BaseStats   map[string]float64
+	Actions     map[string][]string
+	SkillPoints int
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/text"
 	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/world"
 )
 
 type ActorSummary struct {
@@ -54,7 +55,7 @@ func ActorSummaryCreate(actor Actor, showXP bool) ActorSummary {
 		s.XPBar = gui.ProgressBarCreate(
 			0, 0,
 			actor.XP,
-			actor.XP+actor.NextLevelXP,
+			actor.NextLevelXP,
 			"../resources/progressbar_bg.png",
 			"../resources/progressbar_fg.png",
 		)
@@ -144,10 +145,10 @@ func (s *ActorSummary) Render(renderer pixel.Target) {
 	fmt.Fprintln(textBase, level)
 	textBase.Draw(renderer, pixel.IM)
 
-	hp := actor.Stats.Get("HpNow")
-	maxHP := actor.Stats.Get("HpMax")
-	mp := actor.Stats.Get("MpNow")
-	maxMP := actor.Stats.Get("MpMax")
+	hp := actor.Stats.Value(world.StatHpNow)
+	maxHP := actor.Stats.Value(world.StatHpMax)
+	mp := actor.Stats.Value(world.StatMpNow)
+	maxMP := actor.Stats.Value(world.StatMpMax)
 
 	hpTxt := fmt.Sprintf("%v/%v", hp, maxHP)
 	mpTxt := fmt.Sprintf("%v/%v", mp, maxMP)
@@ -178,7 +179,11 @@ func (s *ActorSummary) Render(renderer pixel.Target) {
 		textBase.Draw(renderer, pixel.IM)
 	}
 
-	// MP & HP bars
+	// MP & HP bars, values might have changed e.g. after using a Potion
+	s.HPBar.SetMax(maxHP)
+	s.HPBar.SetValue(hp)
+	s.MPBar.SetMax(maxMP)
+	s.MPBar.SetValue(mp)
 	s.HPBar.Render(renderer)
 	s.MPBar.Render(renderer)
 }
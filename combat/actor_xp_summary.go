@@ -2,6 +2,7 @@ package combat
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/text"
@@ -41,7 +42,7 @@ func ActorXPSummaryCreate(actor *Actor, layout gui.Layout, layoutId string) *Act
 		XPBar: gui.ProgressBarIMDCreate(
 			0, 0,
 			actor.XP,
-			actor.XP+actor.NextLevelXP,
+			actor.NextLevelXP,
 			"#A48B2C",
 			"#00E7DA",
 			3, 100,
@@ -137,10 +138,13 @@ func (a *ActorXPSummary) Render(renderer pixel.Target) {
 	barX := right - a.XPBar.HalfWidth
 	a.XPBar.SetPosition(barX, nameY-24)
 	a.XPBar.SetValue(a.Actor.XP)
-	a.XPBar.SetMax(a.Actor.XP + a.Actor.NextLevelXP)
+	a.XPBar.SetMax(a.Actor.NextLevelXP)
 	a.XPBar.Render(renderer)
 
-	strNextLevel := fmt.Sprintf("Next Level XP: %+6v", a.Actor.NextLevelXP)
+	strNextLevel := fmt.Sprintf("Next Level XP: %+6v", math.Max(0, a.Actor.NextLevelXP-a.Actor.XP))
+	if a.Actor.IsMaxLevel() {
+		strNextLevel = "Max Level"
+	}
 	pos = pixel.V(rightLabel, levelY)
 	textBase = text.New(pos, gui.BasicAtlasAscii)
 	fmt.Fprintln(textBase, strNextLevel)
@@ -0,0 +1,100 @@
+package combat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steelx/go-rpg-cgm/utilz"
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//Affinity how an actor takes damage of an element e.g. world.SpellFire
+type Affinity int
+
+const (
+	AffinityNormal  Affinity = iota
+	AffinityWeak             //double damage
+	AffinityResist           //half damage
+	AffinityImmune           //no damage
+	AffinityAbsorb           //damage heals instead
+	AffinityReflect          //spells bounce back to the caster's side, weapons hit as normal
+)
+
+var affinityNames = []string{"Normal", "Weak", "Resist", "Immune", "Absorb", "Reflect"}
+
+func (a Affinity) String() string {
+	return affinityNames[a]
+}
+
+//Multiplier of damage taken, negative means it heals
+func (a Affinity) Multiplier() float64 {
+	switch a {
+	case AffinityWeak:
+		return 2
+	case AffinityResist:
+		return 0.5
+	case AffinityImmune:
+		return 0
+	case AffinityAbsorb:
+		return -1
+	}
+	return 1
+}
+
+//CombatText shown over the target when it is hit, "" for AffinityNormal
+func (a Affinity) CombatText() string {
+	switch a {
+	case AffinityNormal:
+		return ""
+	case AffinityWeak:
+		return "Weak!"
+	}
+	return a.String()
+}
+
+//Affinity of a for element, attacks without an element are always AffinityNormal
+func (a Actor) Affinity(element string) Affinity {
+	if element == "" {
+		return AffinityNormal
+	}
+	return a.Affinities[element]
+}
+
+//AttackElement element of the equipped weapon, "" if it has none
+func (a Actor) AttackElement() string {
+	for _, slot := range a.EquipSlots {
+		itemId := a.Equipped[slot.Id]
+		if itemId != 0 && world.ItemsDB[itemId].Element != "" {
+			return world.ItemsDB[itemId].Element
+		}
+	}
+	return ""
+}
+
+//AffinityText e.g. "Weak Fire, Absorb Bolt", as scanned in combat
+func (a Actor) AffinityText() string {
+	var parts []string
+	for element, affinity := range a.Affinities {
+		if affinity != AffinityNormal {
+			parts = append(parts, fmt.Sprintf("%v %s", affinity, element))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+//ReflectTarget a random conscious actor of side, where a reflected spell
+//bounces to, nil if none
+func ReflectTarget(side []*Actor) *Actor {
+	var alive []*Actor
+	for _, v := range side {
+		if !v.IsKOed() {
+			alive = append(alive, v)
+		}
+	}
+	if len(alive) == 0 {
+		return nil
+	}
+	return alive[utilz.RandInt(0, len(alive))]
+}
@@ -0,0 +1,80 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestAffinity(t *testing.T) {
+	dragon := ActorFromDef(DragonDef)
+	dragon.Affinities = DragonDef.Affinities
+
+	cases := []struct {
+		element    string
+		affinity   Affinity
+		multiplier float64
+		text       string
+	}{
+		{"", AffinityNormal, 1, ""},
+		{world.SpellFire, AffinityAbsorb, -1, "Absorb"},
+		{world.SpellIce, AffinityWeak, 2, "Weak!"},
+		{world.SpellBolt, AffinityReflect, 1, "Reflect"},
+	}
+	for _, c := range cases {
+		got := dragon.Affinity(c.element)
+		if got != c.affinity || got.Multiplier() != c.multiplier || got.CombatText() != c.text {
+			t.Errorf("%q: got %v x%v %q", c.element, got, got.Multiplier(), got.CombatText())
+		}
+	}
+	if AffinityResist.Multiplier() != 0.5 || AffinityImmune.Multiplier() != 0 {
+		t.Errorf("Resist halves and Immune stops damage")
+	}
+
+	if got := dragon.AffinityText(); got != "Absorb Fire, Reflect Bolt, Weak Ice" {
+		t.Errorf("got %q", got)
+	}
+	hero := ActorFromDef(HeroDef)
+	if got := hero.AffinityText(); got != "" {
+		t.Errorf("no affinities to scan, got %q", got)
+	}
+}
+
+func TestAttackElement(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	if got := hero.AttackElement(); got != "" {
+		t.Errorf("bare hands have no element, got %q", got)
+	}
+	hero.Equipped[hero.EquipSlots[0].Id] = 31 //Frost Brand
+	if got := hero.AttackElement(); got != world.SpellIce {
+		t.Errorf("got %q want %q", got, world.SpellIce)
+	}
+}
+
+func TestReflectTarget(t *testing.T) {
+	hero, mage, thief := ActorFromDef(HeroDef), ActorFromDef(MageDef), ActorFromDef(ThiefDef)
+	side := []*Actor{&hero, &mage, &thief}
+	hero.Stats.Set("HpNow", 0)
+	thief.Stats.Set("HpNow", 0)
+
+	for i := 0; i < 20; i++ {
+		if got := ReflectTarget(side); got != &mage {
+			t.Fatalf("the mage is the only one standing, got %v", got)
+		}
+	}
+
+	thief.Stats.Set("HpNow", 10)
+	picked := make(map[*Actor]bool)
+	for i := 0; i < 200 && len(picked) < 2; i++ {
+		picked[ReflectTarget(side)] = true
+	}
+	if !picked[&mage] || !picked[&thief] || picked[&hero] {
+		t.Errorf("any conscious member can be hit, got %v", picked)
+	}
+
+	mage.Stats.Set("HpNow", 0)
+	thief.Stats.Set("HpNow", 0)
+	if got := ReflectTarget(side); got != nil {
+		t.Errorf("nobody left, got %v", got)
+	}
+}
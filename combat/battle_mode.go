@@ -0,0 +1,51 @@
+package combat
+
+import "math"
+
+//BattleMode how time passes in battle, a setting kept in WorldExtended
+type BattleMode int
+
+const (
+	BattleTurnBased BattleMode = iota //time only passes as events finish, it stops while choosing actions
+	BattleActive                      //ATB, time keeps running while menus are open
+	BattleWait                        //ATB, time stops inside submenus e.g. choosing a target
+)
+
+var battleModeNames = []string{"Turn Based", "Active", "Wait"}
+
+func (m BattleMode) String() string {
+	return battleModeNames[m]
+}
+
+//Next mode, cycling back to the first
+func (m BattleMode) Next() BattleMode {
+	return (m + 1) % BattleMode(len(battleModeNames))
+}
+
+//IsRealTime countdowns drop every second instead of after every event
+func (m BattleMode) IsRealTime() bool {
+	return m != BattleTurnBased
+}
+
+//ATBTimePointsPerSecond how fast countdowns drop in ATB modes
+const ATBTimePointsPerSecond = 100
+
+//ATBGauge fills from 0 to 1 as countDown drops from timePoints to 0
+func ATBGauge(countDown, timePoints float64) float64 {
+	if timePoints <= 0 {
+		return 1
+	}
+	return math.Min(1, math.Max(0, 1-countDown/timePoints))
+}
+
+//TurnsDue actors needing a new turn: conscious, with no event queued and not choosing
+//an action. In ATB modes time runs while a menu is open, after its turn already finished
+func TurnsDue(actors []*Actor, hasEvent, choosing func(*Actor) bool) []*Actor {
+	var due []*Actor
+	for _, v := range actors {
+		if v.Stats.Get("HpNow") > 0 && !hasEvent(v) && !choosing(v) {
+			due = append(due, v)
+		}
+	}
+	return due
+}
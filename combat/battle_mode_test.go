@@ -0,0 +1,72 @@
+package combat
+
+import "testing"
+
+func TestBattleModeCycles(t *testing.T) {
+	mode := BattleTurnBased
+	for _, want := range []BattleMode{BattleActive, BattleWait, BattleTurnBased} {
+		mode = mode.Next()
+		if mode != want {
+			t.Errorf("got %v want %v", mode, want)
+		}
+	}
+	if BattleTurnBased.IsRealTime() || !BattleWait.IsRealTime() {
+		t.Errorf("only ATB modes run in real time")
+	}
+}
+
+func TestATBGauge(t *testing.T) {
+	for _, v := range []struct{ countDown, timePoints, want float64 }{
+		{200, 200, 0},
+		{50, 200, 0.75},
+		{0, 200, 1},
+		{300, 200, 0}, //e.g. a back attack
+		{-1, 0, 1},
+	} {
+		if got := ATBGauge(v.countDown, v.timePoints); got != v.want {
+			t.Errorf("%v/%v: got %v want %v", v.countDown, v.timePoints, got, v.want)
+		}
+	}
+}
+
+func TestTurnsDueWhileChoosing(t *testing.T) {
+	hero, mage := ActorFromDef(HeroDef), ActorFromDef(MageDef)
+	party := []*Actor{&hero, &mage}
+	queued := map[*Actor]bool{}
+	var choosing *Actor
+	hasEvent := func(a *Actor) bool { return queued[a] }
+	isChoosing := func(a *Actor) bool { return a == choosing }
+	addTurns := func() {
+		for _, v := range TurnsDue(party, hasEvent, isChoosing) {
+			queued[v] = true
+		}
+	}
+
+	addTurns()
+	if !queued[&hero] || !queued[&mage] {
+		t.Fatalf("every conscious member gets a turn")
+	}
+
+	//hero's CETurn finishes and opens its menu, in ATB modes time keeps running
+	delete(queued, &hero)
+	choosing = &hero
+	for frame := 0; frame < 3; frame++ {
+		if due := TurnsDue(party, hasEvent, isChoosing); len(due) != 0 {
+			t.Fatalf("frame %v: no turn while choosing, got %v", frame, len(due))
+		}
+	}
+
+	//the chosen action takes the turn's place
+	choosing = nil
+	queued[&hero] = true
+	if due := TurnsDue(party, hasEvent, isChoosing); len(due) != 0 {
+		t.Errorf("no turn while the action is queued, got %v", len(due))
+	}
+
+	delete(queued, &hero)
+	delete(queued, &mage)
+	mage.Stats.Set("HpNow", 0)
+	if due := TurnsDue(party, hasEvent, isChoosing); len(due) != 1 || due[0] != &hero {
+		t.Errorf("the hero's next turn is due once its action finished, not the KOed mage's")
+	}
+}
@@ -0,0 +1,67 @@
+package combat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//ItemDamage e.g. bombs, roll 0..1 picks within Item.Use.Damage,
+//item power doesn't depend on who throws it
+func ItemDamage(magic MagicFormula, target *Actor, item world.Item, roll float64) float64 {
+	min, max := item.Use.Damage[0], item.Use.Damage[1]
+	return math.Floor(magic.Resisted(target, item.Use.Element, min+(max-min)*roll))
+}
+
+//ItemBuffSource stats source of a StatBuff item, using it again replaces the buff
+func ItemBuffSource(item world.Item) world.ModSource {
+	return world.ModSource{Kind: world.SourceItem, Id: item.Name}
+}
+
+//ApplyBuff returns false if Actor is KOed
+func (a *Actor) ApplyBuff(source world.ModSource, buff world.Modifier) bool {
+	if a.IsKOed() {
+		return false
+	}
+	a.Stats.Apply(source, buff)
+	return true
+}
+
+//InflictStatuses adds statuses of attacker's Inflicts to target, returns
+//the ones which stuck in name order. hit tells if a status of chance sticks
+func InflictStatuses(attacker, target *Actor, hit func(chance float64) bool) []string {
+	var statuses []string
+	for status := range attacker.Inflicts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	var inflicted []string
+	for _, status := range statuses {
+		if target.IsKOed() || target.HasStatus(status) || !hit(attacker.Inflicts[status]) {
+			continue
+		}
+		if target.AddStatus(status) {
+			inflicted = append(inflicted, status)
+		}
+	}
+	return inflicted
+}
+
+//ScanText e.g. "Goblin LV 1 HP 30/30 MP 0/0 ATT 4 DEF 2"
+//followed by its affinities on a second line e.g. "Weak Fire"
+func (a Actor) ScanText() string {
+	stats := a.Stats
+	txt := fmt.Sprintf("%s LV %v HP %v/%v MP %v/%v ATT %v DEF %v",
+		a.Name, a.Level,
+		stats.Get("HpNow"), stats.Get("HpMax"),
+		stats.Get("MpNow"), stats.Get("MpMax"),
+		stats.Get("Attack"), stats.Get("Defense"),
+	)
+	if affinities := a.AffinityText(); affinities != "" {
+		txt += "\n" + affinities
+	}
+	return txt
+}
@@ -0,0 +1,102 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestItemDamage(t *testing.T) {
+	goblin, dragon := ActorFromDef(GoblinDef), ActorFromDef(DragonDef)
+	fireBomb := world.ItemsDB[15]
+
+	if got := ItemDamage(RevisedMagic, &goblin, fireBomb, 0); got != 60 {
+		t.Errorf("goblins are weak to fire, got %v", got)
+	}
+	if got := ItemDamage(RevisedMagic, &goblin, fireBomb, 1); got != 90 {
+		t.Errorf("got %v", got)
+	}
+	if got := ItemDamage(RevisedMagic, &dragon, fireBomb, 0); got != -30 {
+		t.Errorf("the dragon absorbs fire, got %v", got)
+	}
+}
+
+func TestCureItems(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.AddStatus(world.StatusPoison)
+	hero.AddStatus(world.StatusBlind)
+	antidote, remedy := world.ItemsDB[17], world.ItemsDB[18]
+
+	if !hero.CureStatus(CureList(antidote)) || hero.HasStatus(world.StatusPoison) || !hero.HasStatus(world.StatusBlind) {
+		t.Errorf("Antidote cures poison only, got %v", hero.Statuses)
+	}
+	if hero.CureStatus(CureList(antidote)) {
+		t.Errorf("nothing left for an Antidote to cure")
+	}
+	if !hero.CureStatus(CureList(remedy)) || hero.HasAnyStatus() {
+		t.Errorf("Remedy cures everything, got %v", hero.Statuses)
+	}
+}
+
+func TestItemBuff(t *testing.T) {
+	hero, mage := ActorFromDef(HeroDef), ActorFromDef(MageDef)
+	drink := world.ItemsDB[19]
+	strength := hero.Stats.Get("Strength")
+
+	if !hero.ApplyBuff(ItemBuffSource(drink), drink.Use.Buff) || hero.Stats.Get("Strength") != strength+10 {
+		t.Errorf("Power Drink adds 10 Strength, got %v", hero.Stats.Get("Strength"))
+	}
+	hero.ApplyBuff(ItemBuffSource(drink), drink.Use.Buff)
+	if hero.Stats.Get("Strength") != strength+10 {
+		t.Errorf("a second drink replaces the first, got %v", hero.Stats.Get("Strength"))
+	}
+	hero.Stats.RemoveSource(ItemBuffSource(drink))
+	if hero.Stats.Get("Strength") != strength {
+		t.Errorf("buff should come off, got %v", hero.Stats.Get("Strength"))
+	}
+
+	mage.Stats.Set("HpNow", 0)
+	if mage.ApplyBuff(ItemBuffSource(drink), drink.Use.Buff) {
+		t.Errorf("KOed actors can't be buffed")
+	}
+}
+
+func TestEscapeItem(t *testing.T) {
+	smokeBomb := world.ItemsDB[20]
+	if smokeBomb.Use.Action != world.Escape || CanUseInField(smokeBomb.Use.Action) {
+		t.Errorf("Smoke Bomb only escapes from combat")
+	}
+}
+
+func TestInflictStatuses(t *testing.T) {
+	goblin, hero := ActorFromDef(GoblinDef), ActorFromDef(HeroDef)
+	always := func(float64) bool { return true }
+
+	if got := InflictStatuses(&goblin, &hero, func(float64) bool { return false }); len(got) != 0 {
+		t.Errorf("missed roll, got %v", got)
+	}
+	if got := InflictStatuses(&goblin, &hero, always); len(got) != 1 || got[0] != world.StatusPoison || !hero.HasStatus(world.StatusPoison) {
+		t.Errorf("goblins poison, got %v", got)
+	}
+	if got := InflictStatuses(&goblin, &hero, always); len(got) != 0 {
+		t.Errorf("already poisoned, got %v", got)
+	}
+
+	thief := ActorFromDef(ThiefDef)
+	thief.Equipped["Accessory1"] = 28 //Serpent Band
+	if got := InflictStatuses(&goblin, &thief, always); len(got) != 0 || thief.HasStatus(world.StatusPoison) {
+		t.Errorf("Serpent Band protects from poison, got %v", got)
+	}
+	if got := InflictStatuses(&hero, &goblin, always); len(got) != 0 {
+		t.Errorf("the hero inflicts nothing, got %v", got)
+	}
+}
+
+func TestScanText(t *testing.T) {
+	goblin := ActorFromDef(GoblinDef)
+	goblin.Stats.Set("HpNow", 45)
+	want := "Goblin LV 0 HP 45/90 MP 0/0 ATT 0 DEF 0\nWeak Fire"
+	if got := goblin.ScanText(); got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
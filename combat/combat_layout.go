@@ -0,0 +1,158 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+//CombatFormation how both sides are placed, see CombatLayout
+type CombatFormation int
+
+const (
+	FormationNormal     CombatFormation = iota //enemies on the left, party on the right
+	FormationPincer                            //the party is split on both sides of the enemies
+	FormationSurrounded                        //the enemies are split on both sides of the party
+)
+
+//All layout values are a percentage of screen width or height, offset from the center of the screen
+const (
+	layoutTop       = 0.1   //highest top edge of an actor
+	layoutBottom    = -0.22 //lowest bottom edge of an actor
+	layoutFront     = 0.2   //distance of the front line from the center
+	layoutFlank     = 0.08  //space between a centered side and the ones flanking it
+	layoutGap       = 0.01  //space between columns
+	layoutStagger   = 0.02  //each actor a bit further back than the one above it
+	layoutFootprint = 0.6   //part of a sprite frame the body fills, frames have empty margins
+)
+
+//LayoutActor what the layout needs to know about a combatant
+type LayoutActor struct {
+	Size pixel.Vec //sprite frame in pixels
+	Back bool      //combat.BackRow
+}
+
+//LayoutSlot where a combatant stands, in pixels from the screen center
+type LayoutSlot struct {
+	Pos     pixel.Vec
+	Flipped bool //on the other side than usual, faces right
+}
+
+//CombatLayout places both sides for formation. Actors are stacked top to bottom
+//in columns, a new column starts further back once one is full or the back row starts.
+//Bodies never overlap. Slots are in the same order as the actors given
+func CombatLayout(formation CombatFormation, partyActors, enemyActors []LayoutActor, screen pixel.Vec) (partySlots, enemySlots []LayoutSlot) {
+	switch formation {
+	case FormationPincer:
+		var half float64
+		enemySlots, half = placeCentered(enemyActors, screen)
+		partySlots = placeSplit(partyActors, half+layoutFlank*screen.X, 1, screen)
+	case FormationSurrounded:
+		var half float64
+		partySlots, half = placeCentered(partyActors, screen)
+		enemySlots = placeSplit(enemyActors, half+layoutFlank*screen.X, -1, screen)
+	default:
+		partySlots, _ = placeGroup(partyActors, layoutFront*screen.X, 1, screen)
+		enemySlots, _ = placeGroup(enemyActors, layoutFront*screen.X, -1, screen)
+	}
+	return
+}
+
+//MirrorSlots swaps the sides e.g. on a back attack
+func MirrorSlots(slots []LayoutSlot) {
+	for i := range slots {
+		slots[i].Pos.X = -slots[i].Pos.X
+		slots[i].Flipped = !slots[i].Flipped
+	}
+}
+
+//placeGroup from front pixels off the center towards dir, returns how far back it goes
+func placeGroup(actors []LayoutActor, front, dir float64, screen pixel.Vec) ([]LayoutSlot, float64) {
+	slots := make([]LayoutSlot, len(actors))
+	top, bottom := layoutTop*screen.Y, layoutBottom*screen.Y
+	stagger, gap := layoutStagger*screen.X, layoutGap*screen.X
+
+	edge := front
+	var column []int
+	flush := func() {
+		height := 0.0
+		for _, i := range column {
+			height += actors[i].Size.Y * layoutFootprint
+		}
+		y := (top+bottom)/2 + height/2
+		width := 0.0
+		for k, i := range column {
+			size := actors[i].Size.Scaled(layoutFootprint)
+			x := edge + stagger*float64(k) + size.X/2
+			slots[i].Pos = pixel.V(dir*x, y-size.Y/2)
+			y -= size.Y
+			width = math.Max(width, stagger*float64(k)+size.X)
+		}
+		if len(column) > 0 {
+			edge += width + gap
+		}
+		column = nil
+	}
+
+	//front row first, the back row starts a new column
+	var order []int
+	for _, back := range []bool{false, true} {
+		for i, a := range actors {
+			if a.Back == back {
+				order = append(order, i)
+			}
+		}
+	}
+
+	height := 0.0
+	for k, i := range order {
+		h := actors[i].Size.Y * layoutFootprint
+		startsBackRow := k > 0 && actors[i].Back && !actors[order[k-1]].Back
+		if startsBackRow || (len(column) > 0 && height+h > top-bottom) {
+			flush()
+			height = 0
+		}
+		column = append(column, i)
+		height += h
+	}
+	flush()
+	return slots, edge - gap
+}
+
+//placeCentered rows are ignored, returns half the width taken
+func placeCentered(actors []LayoutActor, screen pixel.Vec) ([]LayoutSlot, float64) {
+	front := make([]LayoutActor, len(actors))
+	for i, a := range actors {
+		front[i] = LayoutActor{Size: a.Size}
+	}
+	slots, width := placeGroup(front, 0, 1, screen)
+	for i := range slots {
+		slots[i].Pos.X -= width / 2
+	}
+	return slots, width / 2
+}
+
+//placeSplit every other actor goes to the side opposite of dir, facing back
+func placeSplit(actors []LayoutActor, front, dir float64, screen pixel.Vec) []LayoutSlot {
+	var usual, other []LayoutActor
+	for i, a := range actors {
+		if i%2 == 0 {
+			usual = append(usual, a)
+		} else {
+			other = append(other, a)
+		}
+	}
+	usualSlots, _ := placeGroup(usual, front, dir, screen)
+	otherSlots, _ := placeGroup(other, front, -dir, screen)
+
+	slots := make([]LayoutSlot, len(actors))
+	for i := range actors {
+		if i%2 == 0 {
+			slots[i] = usualSlots[i/2]
+		} else {
+			slots[i] = otherSlots[i/2]
+			slots[i].Flipped = true
+		}
+	}
+	return slots
+}
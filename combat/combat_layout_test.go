@@ -0,0 +1,89 @@
+package combat
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/faiface/pixel"
+)
+
+var (
+	layoutHero   = LayoutActor{Size: pixel.V(64, 64)}
+	layoutMage   = LayoutActor{Size: pixel.V(64, 64), Back: true}
+	layoutGoblin = LayoutActor{Size: pixel.V(32, 32)}
+	layoutOgre   = LayoutActor{Size: pixel.V(64, 64)}
+	layoutDragon = LayoutActor{Size: pixel.V(128, 64), Back: true}
+)
+
+//footprint the body of actor standing in slot, see layoutFootprint
+func footprint(actor LayoutActor, slot LayoutSlot) pixel.Rect {
+	half := actor.Size.Scaled(layoutFootprint / 2)
+	return pixel.Rect{Min: slot.Pos.Sub(half), Max: slot.Pos.Add(half)}
+}
+
+func overlaps(a, b pixel.Rect) bool {
+	const epsilon = 1e-9
+	return a.Min.X < b.Max.X-epsilon && b.Min.X < a.Max.X-epsilon &&
+		a.Min.Y < b.Max.Y-epsilon && b.Min.Y < a.Max.Y-epsilon
+}
+
+func TestCombatLayout(t *testing.T) {
+	screen := pixel.V(640, 480)
+	partyMix := []LayoutActor{layoutHero, layoutMage, layoutHero, layoutHero, layoutMage, layoutHero}
+	enemyMixes := map[string][]LayoutActor{
+		"mixed":   {layoutGoblin, layoutDragon, layoutOgre, layoutGoblin, layoutOgre, layoutDragon},
+		"dragons": {layoutDragon, layoutDragon, layoutDragon, layoutDragon, layoutDragon, layoutDragon},
+	}
+
+	for _, formation := range []CombatFormation{FormationNormal, FormationPincer, FormationSurrounded} {
+		for mixName, enemyMix := range enemyMixes {
+			for n := 1; n <= 6; n++ {
+				name := fmt.Sprintf("formation %d %s %d", formation, mixName, n)
+				partyActors, enemyActors := partyMix[:n], enemyMix[:n]
+				partySlots, enemySlots := CombatLayout(formation, partyActors, enemyActors, screen)
+				if len(partySlots) != n || len(enemySlots) != n {
+					t.Fatalf("%s: expected a slot per actor, got %d and %d", name, len(partySlots), len(enemySlots))
+				}
+
+				actors := append(append([]LayoutActor{}, partyActors...), enemyActors...)
+				slots := append(append([]LayoutSlot{}, partySlots...), enemySlots...)
+				for i := range slots {
+					for j := i + 1; j < len(slots); j++ {
+						if a, b := footprint(actors[i], slots[i]), footprint(actors[j], slots[j]); overlaps(a, b) {
+							t.Errorf("%s: actors %d and %d overlap, %v %v", name, i, j, a, b)
+						}
+					}
+				}
+				checkSlotOrder(t, name+" party", partyActors, partySlots)
+				checkSlotOrder(t, name+" enemies", enemyActors, enemySlots)
+			}
+		}
+	}
+}
+
+//checkSlotOrder actors of a row on the same side fill columns top to bottom
+//in the order given, each column further from the center than the last
+func checkSlotOrder(t *testing.T, name string, actors []LayoutActor, slots []LayoutSlot) {
+	for i := range slots {
+		for j := i + 1; j < len(slots); j++ {
+			a, b := slots[i].Pos, slots[j].Pos
+			sameSide := (a.X < 0) == (b.X < 0)
+			if !sameSide || actors[i].Back != actors[j].Back {
+				continue
+			}
+			if a.Y <= b.Y && math.Abs(b.X) <= math.Abs(a.X) {
+				t.Errorf("%s: slot %d %v should come before slot %d %v", name, i, a, j, b)
+			}
+		}
+	}
+}
+
+func TestMirrorSlots(t *testing.T) {
+	party, _ := CombatLayout(FormationNormal, []LayoutActor{layoutHero}, []LayoutActor{layoutGoblin}, pixel.V(640, 480))
+	x := party[0].Pos.X
+	MirrorSlots(party)
+	if party[0].Pos.X != -x || !party[0].Flipped {
+		t.Errorf("got %+v", party[0])
+	}
+}
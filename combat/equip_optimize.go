@@ -0,0 +1,148 @@
+package combat
+
+import (
+	"sort"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//EquipProfile weights stats when comparing equipment,
+//e.g. Offense mostly cares about Attack and Strength
+type EquipProfile struct {
+	Name    string
+	Weights map[string]float64 //BaseStats id -> weight
+}
+
+var EquipProfiles = []EquipProfile{
+	{
+		Name:    "Offense",
+		Weights: map[string]float64{"Attack": 1, "Strength": 1, "Speed": 0.5},
+	},
+	{
+		Name:    "Defense",
+		Weights: map[string]float64{"Defense": 1, "Resist": 0.75, "Speed": 0.25, "HpMax": 0.1},
+	},
+	{
+		Name:    "Magic",
+		Weights: map[string]float64{"Magic": 1, "Intelligence": 1, "Resist": 0.5, "MpMax": 0.1},
+	},
+}
+
+//Score weighted sum of stat differences, e.g. from Actor.PredictStats
+func (p EquipProfile) Score(diffs map[string]float64) float64 {
+	score := 0.0
+	for id, weight := range p.Weights {
+		score += diffs[id] * weight
+	}
+	return score
+}
+
+//OptimizeEquipment picks the best item from inventory for every slot.
+//Returns equip slot id -> item id, only for slots which should change.
+//Actor is left as is, use ApplyEquipment to equip the result
+func (a Actor) OptimizeEquipment(inventory []world.ItemIndex, profile EquipProfile) map[string]int {
+	changes := make(map[string]int)
+	chosen := make(map[int]int) //item id -> copies already picked
+	blocked := make(map[string]bool)
+
+	for _, slot := range a.EquipSlots {
+		if blocked[slot.Id] {
+			continue
+		}
+		bestId, bestScore := -1, 0.0
+
+		for _, v := range inventory {
+			item := world.ItemsDB[v.Id]
+			if chosen[v.Id] >= v.Count || !a.FitsSlot(slot, item) {
+				continue
+			}
+			score := a.equipScore(slot, item, profile)
+			if score > bestScore {
+				bestId, bestScore = v.Id, score
+			}
+		}
+
+		if bestId != -1 {
+			changes[slot.Id] = bestId
+			chosen[bestId]++
+		}
+
+		itemId, ok := changes[slot.Id]
+		if !ok {
+			itemId = a.Equipped[slot.Id]
+		}
+		if slot.Blocks != "" && world.ItemsDB[itemId].TwoHanded {
+			blocked[slot.Blocks] = true
+		}
+	}
+
+	return changes
+}
+
+//equipScore a TwoHanded item also costs whatever is in the slot it Blocks
+func (a Actor) equipScore(slot EquipSlot, item world.Item, profile EquipProfile) float64 {
+	score := profile.Score(a.PredictStats(slot.Id, item))
+	if item.TwoHanded && slot.Blocks != "" && a.Equipped[slot.Blocks] != 0 {
+		score += profile.Score(a.PredictStats(slot.Blocks, world.ItemsDB[0]))
+	}
+	return score
+}
+
+//ApplyEquipment equips result of OptimizeEquipment, replaced items go back to World
+func (a *Actor) ApplyEquipment(changes map[string]int) {
+	for _, slot := range a.EquipSlots {
+		if itemId, ok := changes[slot.Id]; ok {
+			a.Equip(slot.Id, world.ItemsDB[itemId])
+		}
+	}
+}
+
+//ItemComparison how much an Actor gains from an item in its best fitting slot
+type ItemComparison struct {
+	Actor  *Actor
+	SlotId string
+	Diffs  map[string]float64
+	Score  float64
+	CanUse bool
+}
+
+//CompareForParty ranks party members by how much they benefit from item,
+//best first. Members who can't equip it come last
+func (w *WorldExtended) CompareForParty(item world.Item, profile EquipProfile) []ItemComparison {
+	var list []ItemComparison
+	for _, actor := range w.Party.ToArray() {
+		comparison := ItemComparison{Actor: actor}
+
+		for _, slot := range actor.EquipSlots {
+			if !actor.FitsSlot(slot, item) {
+				continue
+			}
+			diffs := actor.PredictStats(slot.Id, item)
+			score := actor.equipScore(slot, item, profile)
+			if !comparison.CanUse || score > comparison.Score {
+				comparison = ItemComparison{
+					Actor:  actor,
+					SlotId: slot.Id,
+					Diffs:  diffs,
+					Score:  score,
+					CanUse: true,
+				}
+			}
+		}
+
+		list = append(list, comparison)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.CanUse != b.CanUse {
+			return a.CanUse
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Actor.Name < b.Actor.Name
+	})
+
+	return list
+}
@@ -0,0 +1,188 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func testInventory(ids ...int) []world.ItemIndex {
+	var list []world.ItemIndex
+	for _, id := range ids {
+		list = append(list, world.ItemIndex{Id: id, Count: 1})
+	}
+	return list
+}
+
+func sameChanges(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOptimizeEquipmentOffense(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	inventory := testInventory(1, 2, 3, 5, 6, 7, 13)
+
+	got := hero.OptimizeEquipment(inventory, EquipProfiles[0])
+	want := map[string]int{"Weapon": 1, "Accessory1": 13, "Accessory2": 3}
+	if !sameChanges(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	if hero.Equipped["Weapon"] != 0 || hero.Stats.Get("Attack") != 0 {
+		t.Errorf("OptimizeEquipment must not change the Actor")
+	}
+}
+
+func TestOptimizeEquipmentRespectsRestrictions(t *testing.T) {
+	mage := ActorFromDef(MageDef)
+	inventory := testInventory(1, 2, 3, 5, 6, 7, 13)
+
+	got := mage.OptimizeEquipment(inventory, EquipProfiles[2])
+	want := map[string]int{"Weapon": 5, "Armor": 6, "Accessory1": 7}
+	if !sameChanges(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestOptimizeEquipmentKeepsBetterGear(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.Equipped["Accessory1"] = 3
+	hero.Stats.Apply(world.EquipSource("Accessory1"), world.ItemsDB[3].Modifier())
+
+	got := hero.OptimizeEquipment(testInventory(7), EquipProfiles[0])
+	if len(got) != 0 {
+		t.Errorf("expected no changes, got %v", got)
+	}
+	if hero.Stats.Get("Strength") != 20 {
+		t.Errorf("Ring of Titan modifier lost, Strength %v", hero.Stats.Get("Strength"))
+	}
+}
+
+func TestApplyEquipment(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(1, 1)
+	w.AddItem(13, 1)
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+
+	hero.ApplyEquipment(hero.OptimizeEquipment(w.Items, EquipProfiles[0]))
+
+	if hero.Equipped["Weapon"] != 1 || hero.Equipped["Accessory1"] != 13 {
+		t.Errorf("unexpected equipment %v", hero.Equipped)
+	}
+	if hero.Stats.Get("Attack") != 5 {
+		t.Errorf("expected Attack 5, got %v", hero.Stats.Get("Attack"))
+	}
+	if len(w.Items) != 0 {
+		t.Errorf("equipped items should leave inventory, got %v", w.Items)
+	}
+}
+
+func TestCompareForParty(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.Party.Add(ActorFromDef(ThiefDef))
+	w.Party.Add(ActorFromDef(MageDef))
+	w.Party.Add(ActorFromDef(HeroDef))
+
+	list := w.CompareForParty(world.ItemsDB[6], EquipProfiles[1])
+	if len(list) != 3 {
+		t.Fatalf("expected 3 party members, got %v", len(list))
+	}
+
+	var ids []string
+	for _, v := range list {
+		ids = append(ids, v.Actor.Id)
+	}
+	if ids[0] != "hero" || ids[1] != "mage" || ids[2] != "thief" {
+		t.Errorf("unexpected order %v", ids)
+	}
+	if list[0].SlotId != "Armor" || list[0].Diffs["Resist"] != 10 {
+		t.Errorf("unexpected comparison %+v", list[0])
+	}
+	if list[2].CanUse {
+		t.Errorf("thief can't wear Dragon's Cloak")
+	}
+}
+
+func TestOptimizeEquipmentTwoHanded(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(1, 1)
+	w.AddItem(22, 1)
+	w.AddItem(23, 1)
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+	hero.Equip("Offhand", world.ItemsDB[23])
+
+	//Great Sword Attack 12 minus Parrying Dagger's 2 still beats Bone Blade
+	changes := hero.OptimizeEquipment(w.Items, EquipProfiles[0])
+	want := map[string]int{"Weapon": 22}
+	if !sameChanges(changes, want) {
+		t.Fatalf("got %v want %v", changes, want)
+	}
+
+	hero.ApplyEquipment(changes)
+	if hero.Equipped["Offhand"] != 0 || w.ItemCount(23) != 1 {
+		t.Errorf("two-handed weapon should return the off hand item, equipped %v", hero.Equipped)
+	}
+	if !hero.IsSlotBlocked("Offhand") || hero.CanEquip("Offhand", world.ItemsDB[23]) {
+		t.Errorf("Offhand should be blocked by Great Sword")
+	}
+	if hero.Stats.Get("Attack") != 12 {
+		t.Errorf("expected Attack 12, got %v", hero.Stats.Get("Attack"))
+	}
+}
+
+func TestEquipRejectsWrongItemType(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(5, 1)
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+
+	if hero.Equip("Weapon", world.ItemsDB[5]) {
+		t.Errorf("hero can't hold a Stave")
+	}
+	if hero.Equipped["Weapon"] != 0 || w.ItemCount(5) != 1 {
+		t.Errorf("rejected item must stay in inventory")
+	}
+}
+
+func TestEquipSameAccessoryTwice(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(3, 2) //Ring of Titan
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+	strength := hero.Stats.Get("Strength")
+
+	if !hero.Equip("Accessory1", world.ItemsDB[3]) || !hero.Equip("Accessory2", world.ItemsDB[3]) {
+		t.Fatalf("two copies of a ring can be worn, got %v", hero.Equipped)
+	}
+	if hero.Stats.Get("Strength") != strength+20 || w.ItemCount(3) != 0 {
+		t.Errorf("both rings should count, got Strength %v", hero.Stats.Get("Strength"))
+	}
+
+	hero.UnEquip("Accessory1")
+	if hero.Stats.Get("Strength") != strength+10 || w.ItemCount(3) != 1 {
+		t.Errorf("the other ring should still count, got Strength %v", hero.Stats.Get("Strength"))
+	}
+}
+
+func TestOptimizeEquipmentCountsCopies(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+
+	got := hero.OptimizeEquipment([]world.ItemIndex{{Id: 3, Count: 2}}, EquipProfiles[0])
+	if want := map[string]int{"Accessory1": 3, "Accessory2": 3}; !sameChanges(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	got = hero.OptimizeEquipment(testInventory(3), EquipProfiles[0])
+	if want := map[string]int{"Accessory1": 3}; !sameChanges(got, want) {
+		t.Errorf("a single copy goes to one slot, got %v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package combat
+
+import "github.com/steelx/go-rpg-cgm/world"
+
+//EquipSlot one equipment slot of a class, e.g. Weapon accepting Sword's
+type EquipSlot struct {
+	Id        string           //key in Actor.Equipped & ActorDef.Equipment
+	Label     string           //shown in menus
+	ItemTypes []world.ItemType //item types allowed in this slot
+	Blocks    string           //slot Id locked while a TwoHanded item is equipped here
+}
+
+//Allows tells if items of itemType fit into this slot
+func (s EquipSlot) Allows(itemType world.ItemType) bool {
+	for _, v := range s.ItemTypes {
+		if v == itemType {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	accessorySlots = []EquipSlot{
+		{Id: "Accessory1", Label: "Accessory 1", ItemTypes: []world.ItemType{world.Accessory}},
+		{Id: "Accessory2", Label: "Accessory 2", ItemTypes: []world.ItemType{world.Accessory}},
+	}
+
+	//DefaultEquipSlots generic layout for classes without their own
+	DefaultEquipSlots = append([]EquipSlot{
+		{Id: "Weapon", Label: "Weapon", ItemTypes: []world.ItemType{world.Weapon}},
+		{Id: "Armor", Label: "Armor", ItemTypes: []world.ItemType{world.Armor}},
+	}, accessorySlots...)
+
+	//HeroEquipSlots swords are held with both hands, daggers in the off hand
+	HeroEquipSlots = append([]EquipSlot{
+		{Id: "Weapon", Label: "Weapon", ItemTypes: []world.ItemType{world.Weapon, world.Sword}, Blocks: "Offhand"},
+		{Id: "Offhand", Label: "Off hand", ItemTypes: []world.ItemType{world.Dagger}},
+		{Id: "Armor", Label: "Armor", ItemTypes: []world.ItemType{world.Armor, world.Plate}},
+	}, accessorySlots...)
+
+	MageEquipSlots = append([]EquipSlot{
+		{Id: "Weapon", Label: "Weapon", ItemTypes: []world.ItemType{world.Weapon, world.Stave}},
+		{Id: "Armor", Label: "Armor", ItemTypes: []world.ItemType{world.Armor, world.Robe}},
+	}, accessorySlots...)
+
+	ThiefEquipSlots = append([]EquipSlot{
+		{Id: "Weapon", Label: "Weapon", ItemTypes: []world.ItemType{world.Weapon, world.Dagger}},
+		{Id: "Armor", Label: "Armor", ItemTypes: []world.ItemType{world.Armor, world.Leather}},
+	}, accessorySlots...)
+)
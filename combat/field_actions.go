@@ -0,0 +1,65 @@
+package combat
+
+import (
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//FieldActions are the world.Action's which can be used outside of combat,
+//from the in-game Items and Magic menus. defI is world.Item or world.SpecialItem,
+//each returns false if target was not affected
+var FieldActions = map[world.Action]func(target *Actor, defI interface{}) bool{
+	world.HpRestore: func(target *Actor, defI interface{}) bool {
+		return target.RestoreHP(RestoreAmount(defI)) > 0
+	},
+	world.MpRestore: func(target *Actor, defI interface{}) bool {
+		return target.RestoreMP(RestoreAmount(defI)) > 0
+	},
+	world.Revive: func(target *Actor, defI interface{}) bool {
+		return target.Revive(RestoreAmount(defI)) > 0
+	},
+	world.CureStatus: func(target *Actor, defI interface{}) bool {
+		return target.CureStatus(CureList(defI))
+	},
+}
+
+//CanUseInField tells if action has a FieldActions entry
+func CanUseInField(action world.Action) bool {
+	_, ok := FieldActions[action]
+	return ok
+}
+
+//ApplyFieldAction applies action to all targets,
+//returns false if none of them were affected, so the item or MP can be kept
+func ApplyFieldAction(action world.Action, defI interface{}, targets []*Actor) bool {
+	actionF, ok := FieldActions[action]
+	if !ok {
+		return false
+	}
+
+	applied := false
+	for _, v := range targets {
+		if actionF(v, defI) {
+			applied = true
+		}
+	}
+	return applied
+}
+
+//RestoreAmount reads the restore value of world.Item or world.SpecialItem
+func RestoreAmount(defI interface{}) float64 {
+	switch def := defI.(type) {
+	case world.Item:
+		return def.Use.Restore
+	case world.SpecialItem:
+		return def.Restore
+	}
+	return 0
+}
+
+//CureList statuses cured by world.Item
+func CureList(defI interface{}) []string {
+	if def, ok := defI.(world.Item); ok {
+		return def.Use.Cures
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package combat
+
+import (
+	"github.com/steelx/go-rpg-cgm/dice"
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+const (
+	JobWarrior = "warrior"
+	JobMage    = "mage"
+	JobThief   = "thief"
+)
+
+//JobLevelXP XP needed in a job to reach JobLevel index+1
+var JobLevelXP = []float64{0, 300, 900, 2000, 4000}
+
+var JobOrder = []string{JobWarrior, JobMage, JobThief}
+
+var JobsDB = map[string]Job{
+	JobWarrior: {
+		Id:   JobWarrior,
+		Name: "Warrior",
+		StatGrowth: map[string]func() int{
+			"HpMax":        dice.Create("2d25+25"),
+			"MpMax":        dice.Create("1d5+2"),
+			"Strength":     world.StatsGrowth.Fast,
+			"Speed":        world.StatsGrowth.Med,
+			"Intelligence": world.StatsGrowth.Slow,
+		},
+		Actions:    []string{ActionAttack, ActionItem, ActionFlee},
+		EquipSlots: HeroEquipSlots,
+		JobGrowth: map[int][]Ability{
+			2: {{Action: ActionPassive, Id: world.PassiveFirstStrike}},
+			4: {{Action: ActionSpecial, Id: world.SpecialSlash}},
+		},
+	},
+	JobMage: {
+		Id:   JobMage,
+		Name: "Mage",
+		StatGrowth: map[string]func() int{
+			"HpMax":        dice.Create("2d25+18"),
+			"MpMax":        dice.Create("1d5+2"),
+			"Strength":     world.StatsGrowth.Slow,
+			"Speed":        world.StatsGrowth.Med,
+			"Intelligence": world.StatsGrowth.Fast,
+		},
+		Actions:    []string{ActionAttack, ActionMagic, ActionItem, ActionFlee},
+		EquipSlots: MageEquipSlots,
+		JobGrowth: map[int][]Ability{
+			1: {{Action: ActionMagic, Id: world.SpellFire}, {Action: ActionMagic, Id: world.SpellHeal}},
+			2: {{Action: ActionMagic, Id: world.SpellIce}, {Action: ActionMagic, Id: world.SpellBolt}},
+			3: {{Action: ActionMagic, Id: world.SpellBurn}, {Action: ActionMagic, Id: world.SpellLife}},
+			4: {{Action: ActionPassive, Id: world.PassiveMpSaver}},
+		},
+	},
+	JobThief: {
+		Id:   JobThief,
+		Name: "Thief",
+		StatGrowth: map[string]func() int{
+			"HpMax":        dice.Create("2d25+20"),
+			"MpMax":        dice.Create("1d10+5"),
+			"Strength":     world.StatsGrowth.Med,
+			"Speed":        world.StatsGrowth.Fast,
+			"Intelligence": world.StatsGrowth.Med,
+		},
+		Actions:    []string{ActionAttack, ActionSpecial, ActionItem, ActionFlee},
+		EquipSlots: ThiefEquipSlots,
+		JobGrowth: map[int][]Ability{
+			1: {{Action: ActionSpecial, Id: world.SpecialSteal}},
+			3: {{Action: ActionPassive, Id: world.PassiveStealBonus}},
+		},
+	},
+}
@@ -0,0 +1,220 @@
+package combat
+
+import "github.com/steelx/go-rpg-cgm/world"
+
+//MaxAbilitySlots abilities from other jobs an Actor can bring along
+const MaxAbilitySlots = 2
+
+//Job sets stat growth, menu actions and equipment of an Actor.
+//Abilities learned in JobGrowth stay with the job, other jobs
+//can only use them through Actor.AbilitySlots
+type Job struct {
+	Id, Name   string
+	StatGrowth map[string]func() int
+	Actions    []string
+	EquipSlots []EquipSlot
+	JobGrowth  map[int][]Ability //job level -> abilities learned
+}
+
+//JobLevel 0 if Actor never had the job
+func (a Actor) JobLevel(jobId string) int {
+	return a.JobLevels[jobId]
+}
+
+//JobAbilities learned in jobId so far, NativeAbilities included for the HomeJob
+func (a Actor) JobAbilities(jobId string) []Ability {
+	list := a.jobGrowthAbilities(jobId)
+	if jobId == a.HomeJob {
+		for _, ab := range a.NativeAbilities {
+			if !hasAbility(list, ab) {
+				list = append(list, ab)
+			}
+		}
+	}
+	return list
+}
+
+//jobGrowthAbilities JobGrowth of jobId up to the current JobLevel
+func (a Actor) jobGrowthAbilities(jobId string) []Ability {
+	var list []Ability
+	job := JobsDB[jobId]
+	for level := 1; level <= a.JobLevel(jobId); level++ {
+		list = append(list, job.JobGrowth[level]...)
+	}
+	return list
+}
+
+//LearnNative adds abilities of the HomeJob e.g. from ActionGrowth,
+//call RefreshJobAbilities to grant them
+func (a *Actor) LearnNative(action string, ids []string) {
+	for _, id := range ids {
+		ab := Ability{Action: action, Id: id}
+		if !hasAbility(a.NativeAbilities, ab) {
+			a.NativeAbilities = append(a.NativeAbilities, ab)
+		}
+	}
+}
+
+//SlottableAbilities learned in other jobs than the current one
+func (a Actor) SlottableAbilities() []Ability {
+	var list []Ability
+	own := a.JobAbilities(a.Job)
+	for _, jobId := range JobOrder {
+		if jobId == a.Job {
+			continue
+		}
+		for _, ab := range a.JobAbilities(jobId) {
+			if !hasAbility(own, ab) && !hasAbility(list, ab) {
+				list = append(list, ab)
+			}
+		}
+	}
+	return list
+}
+
+//JobStatGrowth dice rolled on level up, the ActorDef's own StatGrowth in the HomeJob
+func (a Actor) JobStatGrowth() map[string]func() int {
+	if job, ok := JobsDB[a.Job]; ok && a.Job != a.HomeJob && job.StatGrowth != nil {
+		return job.StatGrowth
+	}
+	return a.StatGrowth
+}
+
+//SetAbilitySlot puts ability learned in another job into slot index,
+//an empty Ability clears the slot
+func (a *Actor) SetAbilitySlot(index int, ab Ability) bool {
+	if index < 0 || index >= MaxAbilitySlots {
+		return false
+	}
+	if ab.Id != "" && (!hasAbility(a.SlottableAbilities(), ab) || hasAbility(a.AbilitySlots, ab)) {
+		return false
+	}
+
+	for len(a.AbilitySlots) < MaxAbilitySlots {
+		a.AbilitySlots = append(a.AbilitySlots, Ability{})
+	}
+	a.AbilitySlots[index] = ab
+	a.RefreshJobAbilities()
+	return true
+}
+
+//ChangeJob swaps the job's Actions, abilities and stat growth for the new one's,
+//abilities of other jobs only come along through AbilitySlots.
+//Equipment that no longer fits goes back to the inventory
+func (a *Actor) ChangeJob(jobId string) bool {
+	job, ok := JobsDB[jobId]
+	if !ok || a.Job == jobId {
+		return false
+	}
+
+	a.Job = jobId
+	if a.JobLevels[jobId] == 0 {
+		a.JobLevels[jobId] = 1
+	}
+
+	//abilities native to the new job don't need a slot
+	own := a.JobAbilities(jobId)
+	for i, ab := range a.AbilitySlots {
+		if hasAbility(own, ab) {
+			a.AbilitySlots[i] = Ability{}
+		}
+	}
+
+	prevSlots := a.EquipSlots
+	if job.EquipSlots != nil {
+		a.EquipSlots = job.EquipSlots
+	}
+	for _, prev := range prevSlots {
+		itemId := a.Equipped[prev.Id]
+		if itemId == 0 {
+			continue
+		}
+		slot, ok := a.GetEquipSlot(prev.Id)
+		if !ok || !a.FitsSlot(slot, world.ItemsDB[itemId]) {
+			a.UnEquip(prev.Id)
+		}
+	}
+
+	a.RefreshJobAbilities()
+	return true
+}
+
+//RefreshJobAbilities grants the current job's Actions and abilities
+//plus the slotted ones, equipment is lent again on top.
+//NativeAbilities are known in the HomeJob but their menu action
+//stays locked until ActionGrowth unlocks it, see NativeActions
+func (a *Actor) RefreshJobAbilities() {
+	a.takeBack(a.LentAbilities, a.lentActions)
+	a.LentAbilities = make(map[string]string)
+	a.lentActions = nil
+
+	a.takeBack(a.JobGranted, a.jobActions)
+	a.JobGranted = make(map[string]string)
+	a.jobActions = nil
+
+	var actions []string
+	if job, ok := JobsDB[a.Job]; ok {
+		actions = job.Actions
+	}
+	if a.Job == a.HomeJob {
+		actions = append(actions[:len(actions):len(actions)], a.NativeActions...)
+		for _, ab := range a.NativeAbilities {
+			a.grant(ab, a.JobGranted, nil)
+		}
+	}
+	for _, action := range actions {
+		if !a.HasAction(action) {
+			a.Actions = append(a.Actions, action)
+			a.jobActions = append(a.jobActions, action)
+		}
+	}
+	for _, ab := range a.jobGrowthAbilities(a.Job) {
+		a.grant(ab, a.JobGranted, &a.jobActions)
+	}
+	for _, ab := range a.AbilitySlots {
+		if ab.Id != "" {
+			a.grant(ab, a.JobGranted, &a.jobActions)
+		}
+	}
+
+	a.RefreshLentAbilities()
+}
+
+//jobPassives passives of the current job and ability slots, see ActivePassives
+func (a Actor) jobPassives() []string {
+	var passives []string
+	for _, ab := range append(a.JobAbilities(a.Job), a.AbilitySlots...) {
+		if ab.Action == ActionPassive {
+			passives = append(passives, ab.Id)
+		}
+	}
+	return passives
+}
+
+//AddJobXP levels up the current job, returns abilities learned by it
+func (a *Actor) AddJobXP(xp float64) []Ability {
+	if _, ok := JobsDB[a.Job]; !ok {
+		return nil
+	}
+
+	a.JobXP[a.Job] += xp
+	var learned []Ability
+	for a.JobLevels[a.Job] < len(JobLevelXP) && a.JobXP[a.Job] >= JobLevelXP[a.JobLevels[a.Job]] {
+		a.JobLevels[a.Job]++
+		learned = append(learned, JobsDB[a.Job].JobGrowth[a.JobLevels[a.Job]]...)
+	}
+
+	if len(learned) > 0 {
+		a.RefreshJobAbilities()
+	}
+	return learned
+}
+
+func hasAbility(list []Ability, ab Ability) bool {
+	for _, v := range list {
+		if v == ab {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package combat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestChangeJob(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.AddItem(1, 1)
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+	hero.Equip("Weapon", world.ItemsDB[1])
+
+	if !hero.ChangeJob(JobMage) {
+		t.Fatalf("expected job change")
+	}
+	if !hasString(hero.Magic, world.SpellFire) || !hero.HasAction(ActionMagic) {
+		t.Errorf("Mage job should grant Fire, got %v %v", hero.Magic, hero.Actions)
+	}
+	if hero.Equipped["Weapon"] != 0 || w.ItemCount(1) != 1 {
+		t.Errorf("Bone Blade doesn't fit a Mage weapon slot, equipped %v", hero.Equipped)
+	}
+	if _, ok := hero.GetEquipSlot("Offhand"); ok {
+		t.Errorf("Mage has no off hand slot")
+	}
+
+	hero.ChangeJob(JobWarrior)
+	if hasString(hero.Magic, world.SpellFire) || hero.HasAction(ActionMagic) {
+		t.Errorf("Fire should stay with the Mage job, got %v %v", hero.Magic, hero.Actions)
+	}
+	if hero.JobLevel(JobMage) != 1 {
+		t.Errorf("job level should persist")
+	}
+}
+
+func TestAbilitySlots(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.ChangeJob(JobMage)
+	if learned := hero.AddJobXP(JobLevelXP[1]); len(learned) != 2 {
+		t.Fatalf("expected Ice & Bolt at Mage level 2, got %v", learned)
+	}
+	hero.ChangeJob(JobWarrior)
+
+	burn := Ability{Action: ActionMagic, Id: world.SpellBurn}
+	if hero.SetAbilitySlot(0, burn) {
+		t.Errorf("Burn is not learned yet")
+	}
+
+	ice := Ability{Action: ActionMagic, Id: world.SpellIce}
+	if !hero.SetAbilitySlot(0, ice) || !hasString(hero.Magic, world.SpellIce) {
+		t.Fatalf("slotted Ice should be castable, got %v", hero.Magic)
+	}
+	if hero.SetAbilitySlot(1, ice) {
+		t.Errorf("same ability can't take two slots")
+	}
+	if hasString(hero.Magic, world.SpellFire) {
+		t.Errorf("only slotted abilities come along, got %v", hero.Magic)
+	}
+
+	hero.SetAbilitySlot(0, Ability{})
+	if hasString(hero.Magic, world.SpellIce) || hero.HasAction(ActionMagic) {
+		t.Errorf("cleared slot should take Ice back, got %v %v", hero.Magic, hero.Actions)
+	}
+}
+
+func TestNativeAbilitiesStayWithHomeJob(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.ApplyLevel(LevelUp{Level: 5, Actions: HeroDef.ActionGrowth[5]})
+	if !hasString(hero.Special, world.SpecialSlash) || !hero.HasAction(ActionSpecial) {
+		t.Fatalf("level 5 should unlock Slash, got %v %v", hero.Special, hero.Actions)
+	}
+
+	hero.ChangeJob(JobMage)
+	if hasString(hero.Special, world.SpecialSlash) || hero.HasAction(ActionSpecial) {
+		t.Errorf("Slash belongs to the Warrior job, got %v %v", hero.Special, hero.Actions)
+	}
+	slash := Ability{Action: ActionSpecial, Id: world.SpecialSlash}
+	if !hero.SetAbilitySlot(0, slash) || !hasString(hero.Special, world.SpecialSlash) || !hero.HasAction(ActionSpecial) {
+		t.Errorf("slotted Slash should come along, got %v %v", hero.Special, hero.Actions)
+	}
+
+	mage := ActorFromDef(MageDef)
+	mage.ChangeJob(JobThief)
+	if len(mage.Magic) != 0 || mage.HasAction(ActionMagic) {
+		t.Errorf("a Thief can't cast the Mage's spells, got %v %v", mage.Magic, mage.Actions)
+	}
+	if !hasString(mage.Special, world.SpecialSteal) || !mage.HasAction(ActionSpecial) {
+		t.Errorf("Thief job should grant Steal, got %v %v", mage.Special, mage.Actions)
+	}
+	mage.ChangeJob(JobMage)
+	if !hasString(mage.Magic, world.SpellBurn) || !mage.HasAction(ActionMagic) {
+		t.Errorf("native spells are back with the Mage job, got %v %v", mage.Magic, mage.Actions)
+	}
+}
+
+func TestStatGrowthFollowsJob(t *testing.T) {
+	sameTable := func(a, b map[string]func() int) bool {
+		return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+	}
+	hero := ActorFromDef(HeroDef)
+	if !sameTable(hero.JobStatGrowth(), HeroDef.StatGrowth) {
+		t.Errorf("the HomeJob grows as the ActorDef")
+	}
+	hero.ChangeJob(JobMage)
+	if !sameTable(hero.JobStatGrowth(), JobsDB[JobMage].StatGrowth) {
+		t.Errorf("a Mage grows as the Mage job")
+	}
+	hero.ChangeJob(JobWarrior)
+	if !sameTable(hero.JobStatGrowth(), HeroDef.StatGrowth) {
+		t.Errorf("back in the HomeJob the ActorDef's growth returns")
+	}
+}
+
+func TestRestrictionsAcceptJobIds(t *testing.T) {
+	mage := ActorFromDef(MageDef)
+	mage.ChangeJob(JobWarrior)
+	if !mage.CanUse(world.ItemsDB[1]) {
+		t.Errorf("a Warrior can use the Bone Blade")
+	}
+	if mage.CanUse(world.ItemsDB[5]) || mage.CanUse(world.ItemsDB[25]) {
+		t.Errorf("the Mage job's gear doesn't fit the mage as a Warrior")
+	}
+	mage.ChangeJob(JobThief)
+	if mage.CanUse(world.ItemsDB[5]) || !mage.CanUse(world.ItemsDB[8]) {
+		t.Errorf("a Thief uses the Black Dagger, not the World Tree Branch")
+	}
+
+	hero := ActorFromDef(HeroDef)
+	hero.ChangeJob(JobMage)
+	if !hero.CanUse(world.ItemsDB[5]) || !hero.CanUse(world.ItemsDB[1]) {
+		t.Errorf("the hero as a Mage uses Mage gear and its own Bone Blade")
+	}
+}
@@ -2,17 +2,52 @@ package combat
 
 import "math"
 
-//exponent represents the increase of difficulty between levels
-//Disgea Level formula to the first 99 levels.
-func NextLevel(level int) float64 {
-	exponent := 1
-	baseXP := 1000.0
-	//return math.Round( 0.04 * float64(level ^ 3) + 0.8 * float64(level ^ 2) + float64(2 * level))
-	return math.Round(baseXP*float64(level^exponent) + 0.8*float64(level^2) + float64(2*level))
+//XPCurve XP needed to go from a level to the next. Either an explicit Table
+//or the formula Base*n^Exponent + Quadratic*n^2 + Linear*n, n being the level reached
+type XPCurve struct {
+	Base, Exponent    float64
+	Quadratic, Linear float64
+	Table             []float64 //Table[level] XP needed to reach level+1, overrides the formula
+	LevelCap          int       //0 means no cap other than the Table length
+}
+
+//DefaultXPCurve Disgaea like curve, used by actors without their own
+var DefaultXPCurve = XPCurve{Base: 1000, Exponent: 1.5, Quadratic: 0.8, Linear: 2, LevelCap: 99}
+
+//NextLevel XP needed to go from level to level+1
+func (c XPCurve) NextLevel(level int) float64 {
+	if c.Table != nil {
+		if level >= len(c.Table) {
+			return c.Table[len(c.Table)-1]
+		}
+		return c.Table[level]
+	}
+	n := float64(level + 1)
+	return math.Round(c.Base*math.Pow(n, c.Exponent) + c.Quadratic*math.Pow(n, 2) + c.Linear*n)
+}
+
+//MaxLevel 0 if there is no cap
+func (c XPCurve) MaxLevel() int {
+	if c.Table != nil && (c.LevelCap == 0 || c.LevelCap > len(c.Table)) {
+		return len(c.Table)
+	}
+	return c.LevelCap
 }
 
-//func NextLevel_(level int) float64 {
-//	exponent := 2
-//	baseXP := 1000.0
-//	return math.Floor(baseXP * float64(level^exponent))
-//}
+func (c XPCurve) IsMaxLevel(level int) bool {
+	return c.MaxLevel() > 0 && level >= c.MaxLevel()
+}
+
+//Requirements XP needed for each of the count levels after level, stops at the cap
+func (c XPCurve) Requirements(level, count int) []float64 {
+	var list []float64
+	for l := level; l < level+count && !c.IsMaxLevel(l); l++ {
+		list = append(list, c.NextLevel(l))
+	}
+	return list
+}
+
+//NextLevel XP needed to go from level to level+1 on DefaultXPCurve
+func NextLevel(level int) float64 {
+	return DefaultXPCurve.NextLevel(level)
+}
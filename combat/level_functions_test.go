@@ -0,0 +1,62 @@
+package combat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestXPCurveUsesPowers(t *testing.T) {
+	c := XPCurve{Base: 1000, Exponent: 2, Linear: 1}
+	for level := 0; level < 10; level++ {
+		n := float64(level + 1)
+		if got, want := c.NextLevel(level), 1000*n*n+n; got != want {
+			t.Errorf("level %d: got %v want %v", level, got, want)
+		}
+	}
+
+	prev := 0.0
+	for level := 0; level < 99; level++ {
+		xp := NextLevel(level)
+		if xp <= prev {
+			t.Fatalf("DefaultXPCurve must grow, level %d needs %v after %v", level, xp, prev)
+		}
+		prev = xp
+	}
+	if NextLevel(3) != math.Round(1000*math.Pow(4, 1.5)+0.8*16+8) {
+		t.Errorf("unexpected DefaultXPCurve value %v", NextLevel(3))
+	}
+}
+
+func TestXPCurveTableAndCap(t *testing.T) {
+	c := XPCurve{Table: []float64{10, 20, 30}}
+	if c.NextLevel(1) != 20 || c.MaxLevel() != 3 {
+		t.Errorf("got %v max %v", c.NextLevel(1), c.MaxLevel())
+	}
+	if got := c.Requirements(1, 5); len(got) != 2 || got[1] != 30 {
+		t.Errorf("requirements should stop at the cap, got %v", got)
+	}
+
+	c.LevelCap = 2
+	if c.MaxLevel() != 2 || !c.IsMaxLevel(2) {
+		t.Errorf("LevelCap below table length wins, got %v", c.MaxLevel())
+	}
+}
+
+func TestMultipleLevelUps(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.XPCurve = XPCurve{Table: []float64{100, 200, 300}}
+	hero.NextLevelXP = hero.XPCurve.NextLevel(0)
+
+	hero.AddXP(1000)
+	levels := 0
+	for hero.ReadyToLevelUp() {
+		hero.ApplyLevel(hero.CreateLevelUp())
+		levels++
+	}
+	if levels != 3 || hero.Level != 3 || hero.XP != 400 {
+		t.Errorf("expected 3 level ups with 400 XP left, got %d level %d XP %v", levels, hero.Level, hero.XP)
+	}
+	if !hero.IsMaxLevel() || hero.SkillPoints != 3*SkillPointsPerLevel {
+		t.Errorf("expected max level with skill points, got %v", hero.SkillPoints)
+	}
+}
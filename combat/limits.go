@@ -0,0 +1,36 @@
+package combat
+
+import "math"
+
+const (
+	LimitGaugeMax   = 100.0
+	LimitDamageFill = 150.0 //filled by losing HpMax, a hit taking a third of it fills half the gauge
+	LimitAllyKOFill = 30.0  //filled for every living ally when a party member is KO'd
+)
+
+//FillLimit adds points to the limit gauge, actors without a Limit don't have one
+func (a *Actor) FillLimit(points float64) {
+	if a.Limit == "" || a.IsKOed() {
+		return
+	}
+	a.LimitGauge = math.Min(LimitGaugeMax, a.LimitGauge+points)
+}
+
+//FillLimitFromDamage fills the gauge in proportion to the share of HpMax lost
+func (a *Actor) FillLimitFromDamage(damage float64) {
+	hpMax := a.Stats.Get("HpMax")
+	if damage <= 0 || hpMax <= 0 {
+		return
+	}
+	a.FillLimit(damage / hpMax * LimitDamageFill)
+}
+
+//LimitReady ActionLimit is offered in combat
+func (a Actor) LimitReady() bool {
+	return a.Limit != "" && a.LimitGauge >= LimitGaugeMax
+}
+
+//UseLimit empties the gauge
+func (a *Actor) UseLimit() {
+	a.LimitGauge = 0
+}
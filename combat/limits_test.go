@@ -0,0 +1,42 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestLimitGauge(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.Limit = ""
+	hero.FillLimitFromDamage(50)
+	if hero.LimitGauge != 0 {
+		t.Errorf("no Limit, no gauge, got %v", hero.LimitGauge)
+	}
+
+	hero.Limit = world.LimitBraveBlade
+	hero.FillLimitFromDamage(8) //a fifth of HpMax
+	if hero.LimitGauge != 30 || hero.LimitReady() {
+		t.Errorf("got %v", hero.LimitGauge)
+	}
+	hero.FillLimit(LimitAllyKOFill)
+	hero.FillLimitFromDamage(100)
+	if hero.LimitGauge != LimitGaugeMax || !hero.LimitReady() {
+		t.Errorf("gauge must stop when full, got %v", hero.LimitGauge)
+	}
+
+	hero.UseLimit()
+	hero.Stats.Set("HpNow", 0)
+	hero.FillLimit(LimitAllyKOFill)
+	if hero.LimitGauge != 0 {
+		t.Errorf("KO'd actors don't charge, got %v", hero.LimitGauge)
+	}
+}
+
+func TestPartyLimitsExist(t *testing.T) {
+	for id, def := range PartyMembersDefinitions {
+		if _, ok := world.LimitsDB[def.Limit]; !ok {
+			t.Errorf("%s: Limit %q not in LimitsDB", id, def.Limit)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//MagicFormula spell damage, healing power and status hit chance,
+//chosen by game_map.FormulaT.Magic
+type MagicFormula struct {
+	Name         string
+	Power        func(caster *Actor) float64                       //magic power
+	Defense      func(target *Actor) float64                       //share of spell damage and status chance resisted, 0..1
+	SpellDamage  func(caster *Actor, base float64) float64         //base is rolled within SpecialItem.BaseDamage, Defense is left to the caller
+	HealPower    func(caster *Actor, amount float64) float64       //HP restored by a spell of amount
+	StatusChance func(caster, target *Actor, base float64) float64 //chance a status of base chance sticks
+}
+
+//ResistHalf Resist which halves spell damage in RevisedMagic
+const ResistHalf = 50.0
+
+//ClassicMagic only Intelligence powers spells, Resist counts against 255
+//and healing spells restore a fixed amount
+var ClassicMagic = MagicFormula{
+	Name: "Classic",
+	Power: func(caster *Actor) float64 {
+		return caster.Stats.Get("Intelligence")
+	},
+	Defense: func(target *Actor) float64 {
+		return target.Stats.Value(world.StatMagicDefense)
+	},
+	SpellDamage: func(caster *Actor, base float64) float64 {
+		//Damage = Spell Power * 4 + (Level * Magic Power * Spell Power / 32)
+		return base*4 + float64(caster.Level)*caster.Stats.Get("Intelligence")*(base/32)
+	},
+	HealPower: func(caster *Actor, amount float64) float64 {
+		return amount
+	},
+	StatusChance: func(caster, target *Actor, base float64) float64 {
+		return base * (1 - target.Stats.Value(world.StatMagicDefense))
+	},
+}
+
+//RevisedMagic magic power is Intelligence plus Magic from equipment and
+//magic defense comes from Resist with diminishing returns, see ResistHalf
+var RevisedMagic = MagicFormula{
+	Name:    "Revised",
+	Power:   MagicPower,
+	Defense: ResistDefense,
+	SpellDamage: func(caster *Actor, base float64) float64 {
+		return base*4 + float64(caster.Level)*MagicPower(caster)*(base/32)
+	},
+	HealPower: func(caster *Actor, amount float64) float64 {
+		return math.Floor(amount * (1 + MagicPower(caster)/100))
+	},
+	StatusChance: func(caster, target *Actor, base float64) float64 {
+		chance := base * (1 + MagicPower(caster)/200) * (1 - ResistDefense(target))
+		return math.Max(0, math.Min(1, chance))
+	},
+}
+
+//MagicPower Intelligence plus Magic granted by equipment e.g. a stave
+func MagicPower(a *Actor) float64 {
+	return a.Stats.Get("Intelligence") + a.Stats.Get("Magic")
+}
+
+//ResistDefense Resist / (Resist + ResistHalf), capped like world.StatMagicDefense
+func ResistDefense(a *Actor) float64 {
+	resist := a.Stats.Get("Resist")
+	return world.StatCaps[world.StatMagicDefense].Apply(resist / (resist + ResistHalf))
+}
+
+//Resisted elemental weakness / strength, magic defense and Affinity of target,
+//negative damage means the target absorbs it
+func (m MagicFormula) Resisted(target *Actor, element string, damage float64) float64 {
+	// Apply elemental weakness / strength modifications
+	if modifier, ok := target.Stats.Lookup(element); ok {
+		damage += damage * modifier
+	}
+	// Handle resistance, see Defense
+	damage *= 1 - m.Defense(target)
+	return damage * target.Affinity(element).Multiplier()
+}
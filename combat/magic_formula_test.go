@@ -0,0 +1,66 @@
+package combat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestMagicFormulas(t *testing.T) {
+	mage := ActorFromDef(MageDef)
+	mage.Level = 2
+	goblin := ActorFromDef(GoblinDef)
+	//World Tree Branch Magic +5, a robe of Resist 50
+	mage.Stats.Apply(world.EquipSource("Weapon"), world.ItemsDB[5].Modifier())
+	goblin.Stats.Apply(world.EquipSource("Armor"), world.Modifier{Mod: world.Mod{Add: world.BaseStats{Resist: 50}}})
+
+	cases := []struct {
+		name      string
+		got, want float64
+	}{
+		{"classic power", ClassicMagic.Power(&mage), 20},
+		{"classic damage", ClassicMagic.SpellDamage(&mage, 16), 16*4 + 2*20*0.5},
+		{"classic defense", ClassicMagic.Defense(&goblin), 50.0 / 255},
+		{"classic heal", ClassicMagic.HealPower(&mage, 60), 60},
+		{"classic status", ClassicMagic.StatusChance(&mage, &goblin, 0.5), 0.5 * (1 - 50.0/255)},
+		{"revised power", RevisedMagic.Power(&mage), 25},
+		{"revised damage", RevisedMagic.SpellDamage(&mage, 16), 16*4 + 2*25*0.5},
+		{"revised defense", RevisedMagic.Defense(&goblin), 0.5},
+		{"revised heal", RevisedMagic.HealPower(&mage, 60), 75},
+		{"revised status", RevisedMagic.StatusChance(&mage, &goblin, 0.5), 0.5 * 1.125 * 0.5},
+	}
+	for _, c := range cases {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s: got %v want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestResistDefenseCapped(t *testing.T) {
+	goblin := ActorFromDef(GoblinDef)
+	goblin.Stats.Apply(world.EquipSource("Armor"), world.Modifier{Mod: world.Mod{Add: world.BaseStats{Resist: 999}}})
+	//Resist caps at 255, 255/305 is then softened past 0.6
+	want := 0.6 + (255.0/305-0.6)*0.5
+	if got := ResistDefense(&goblin); math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v want %v", got, want)
+	}
+	if got := RevisedMagic.StatusChance(&goblin, &goblin, 5); got != 1 {
+		t.Errorf("status chance can't pass 1, got %v", got)
+	}
+}
+
+func TestResisted(t *testing.T) {
+	goblin, dragon := ActorFromDef(GoblinDef), ActorFromDef(DragonDef)
+	goblin.Stats.Apply(world.EquipSource("Armor"), world.Modifier{Mod: world.Mod{Add: world.BaseStats{Resist: 50}}})
+
+	if got := RevisedMagic.Resisted(&goblin, world.SpellFire, 40); got != 40 {
+		t.Errorf("half resisted, then doubled by the fire weakness, got %v", got)
+	}
+	if got := RevisedMagic.Resisted(&dragon, world.SpellFire, 40); got != -40 {
+		t.Errorf("the dragon absorbs fire, got %v", got)
+	}
+	if got := RevisedMagic.Resisted(&dragon, "", 40); got != 40 {
+		t.Errorf("no element, got %v", got)
+	}
+}
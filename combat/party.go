@@ -1,18 +1,28 @@
 package combat
 
+//MaxActiveMembers party members that go into battle, combat layouts have room for 3
+const MaxActiveMembers = 3
+
 type Party struct {
-	Members map[string]*Actor
-	World   *WorldExtended
+	Members   map[string]*Actor
+	Order     []string //Members ids in formation order, see Active and Reserve
+	MaxActive int
+	World     *WorldExtended
 }
 
 func PartyCreate(w *WorldExtended) *Party {
 	return &Party{
-		Members: make(map[string]*Actor),
-		World:   w,
+		Members:   make(map[string]*Actor),
+		MaxActive: MaxActiveMembers,
+		World:     w,
 	}
 }
 
+//Add joins the end of the formation, on the reserve bench once the active roster is full
 func (p *Party) Add(member Actor) {
+	if _, ok := p.Members[member.Id]; !ok {
+		p.Order = append(p.Order, member.Id)
+	}
 	p.Members[member.Id] = &member
 	p.Members[member.Id].worldRef = p.World
 }
@@ -21,12 +31,66 @@ func (p *Party) Remove(member Actor) {
 }
 func (p *Party) removeById(id string) {
 	delete(p.Members, id)
+	if i := p.FormationIndex(id); i != -1 {
+		p.Order = append(p.Order[:i], p.Order[i+1:]...)
+	}
 }
 
+//ToArray every member in formation order
 func (p Party) ToArray() []*Actor {
 	var party []*Actor
-	for _, v := range p.Members {
-		party = append(party, v)
+	for _, id := range p.Order {
+		party = append(party, p.Members[id])
 	}
 	return party
 }
+
+//Active members that go into battle, in formation order
+func (p Party) Active() []*Actor {
+	all := p.ToArray()
+	if len(all) > p.MaxActive {
+		return all[:p.MaxActive]
+	}
+	return all
+}
+
+//Reserve members waiting on the bench
+func (p Party) Reserve() []*Actor {
+	all := p.ToArray()
+	if len(all) > p.MaxActive {
+		return all[p.MaxActive:]
+	}
+	return nil
+}
+
+//FormationIndex -1 if id is not in the party
+func (p Party) FormationIndex(id string) int {
+	for i, memberId := range p.Order {
+		if memberId == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p Party) IsActive(id string) bool {
+	i := p.FormationIndex(id)
+	return i != -1 && i < p.MaxActive
+}
+
+//Swap exchanges the places of two members, moving them between
+//the active roster and the bench. At least one active member must be able to fight
+func (p *Party) Swap(idA, idB string) bool {
+	a, b := p.FormationIndex(idA), p.FormationIndex(idB)
+	if a == -1 || b == -1 || a == b {
+		return false
+	}
+	p.Order[a], p.Order[b] = p.Order[b], p.Order[a]
+	for _, actor := range p.Active() {
+		if !actor.IsKOed() {
+			return true
+		}
+	}
+	p.Order[a], p.Order[b] = p.Order[b], p.Order[a]
+	return false
+}
@@ -0,0 +1,59 @@
+package combat
+
+import "testing"
+
+func testFullParty() *Party {
+	p := PartyCreate(WorldExtendedCreate())
+	p.MaxActive = 2
+	for _, id := range []string{"hero", "mage", "thief"} {
+		p.Add(ActorFromDef(PartyMembersDefinitions[id]))
+	}
+	return p
+}
+
+func partyIds(actors []*Actor) (ids []string) {
+	for _, a := range actors {
+		ids = append(ids, a.Id)
+	}
+	return ids
+}
+
+func TestPartyOrder(t *testing.T) {
+	p := testFullParty()
+	for i := 0; i < 10; i++ {
+		if got := partyIds(p.ToArray()); len(got) != 3 || got[0] != "hero" || got[1] != "mage" || got[2] != "thief" {
+			t.Fatalf("ToArray must follow joining order, got %v", got)
+		}
+	}
+	if got := partyIds(p.Reserve()); len(got) != 1 || got[0] != "thief" {
+		t.Errorf("got %v", got)
+	}
+
+	p.Add(*p.Members["hero"])
+	p.Remove(*p.Members["mage"])
+	if got := partyIds(p.Active()); len(got) != 2 || got[0] != "hero" || got[1] != "thief" {
+		t.Errorf("re-adding keeps the place, removing frees one, got %v", got)
+	}
+}
+
+func TestPartySwap(t *testing.T) {
+	p := testFullParty()
+	if !p.Swap("mage", "thief") {
+		t.Fatalf("swap failed")
+	}
+	if !p.IsActive("thief") || p.IsActive("mage") || p.FormationIndex("thief") != 1 {
+		t.Errorf("got %v", p.Order)
+	}
+
+	p.Members["hero"].Stats.Set("HpNow", 0)
+	p.Members["mage"].Stats.Set("HpNow", 0)
+	if p.Swap("thief", "mage") {
+		t.Errorf("no active member could fight")
+	}
+	if p.Swap("hero", "nobody") || p.Swap("hero", "hero") {
+		t.Errorf("invalid swaps")
+	}
+	if got := partyIds(p.ToArray()); got[0] != "hero" || got[1] != "thief" || got[2] != "mage" {
+		t.Errorf("failed swaps must not change the order, got %v", got)
+	}
+}
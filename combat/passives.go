@@ -0,0 +1,84 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//ActivePassives world.PassivesDB ids from learned Passives, Job, Skills,
+//equipped items, passives they teach and statuses. An id shows up once per source
+func (a Actor) ActivePassives() []string {
+	passives := append(a.jobPassives(), a.Passives...)
+	passives = append(passives, a.skillPassives()...)
+	for _, slot := range a.EquipSlots {
+		itemId := a.Equipped[slot.Id]
+		passives = append(passives, world.ItemsDB[itemId].Passives...)
+	}
+	for _, t := range a.Teachables() {
+		if t.Action == ActionPassive && !a.IsMastered(t) {
+			passives = append(passives, t.Id)
+		}
+	}
+	for status := range a.Statuses {
+		passives = append(passives, world.StatusPassives[status]...)
+	}
+	return passives
+}
+
+func (a Actor) HasPassive(passiveId string) bool {
+	return hasString(a.ActivePassives(), passiveId)
+}
+
+//PassiveValue stacked Value of passiveId, 0 if not active
+func (a Actor) PassiveValue(passiveId string) float64 {
+	total := 0.0
+	for _, v := range a.ActivePassives() {
+		if v == passiveId {
+			total += world.PassivesDB[v].Value
+		}
+	}
+	return total
+}
+
+//IsImmune tells if any active passive protects from status
+func (a Actor) IsImmune(status string) bool {
+	for _, v := range a.ActivePassives() {
+		if hasString(world.PassivesDB[v].Immune, status) {
+			return true
+		}
+	}
+	return false
+}
+
+//LearnPassives for good e.g. mastered from equipment, ActionGrowth passives are NativeAbilities
+func (a *Actor) LearnPassives(passiveIds []string) {
+	for _, v := range passiveIds {
+		if !hasString(a.Passives, v) {
+			a.Passives = append(a.Passives, v)
+		}
+	}
+}
+
+//MpCost what a spell or special of given cost costs this Actor
+func (a Actor) MpCost(cost float64) float64 {
+	saved := math.Min(0.9, a.PassiveValue(world.PassiveMpSaver))
+	return math.Ceil(cost * (1 - saved))
+}
+
+//XPMultiplier applied to XP earned from combat
+func (a Actor) XPMultiplier() float64 {
+	if a.HasPassive(world.PassiveDoubleXP) {
+		return 2
+	}
+	return 1
+}
+
+//Regenerate restores HP at the start of a turn, returns HP restored
+func (a *Actor) Regenerate() float64 {
+	regen := a.PassiveValue(world.PassiveRegen)
+	if regen <= 0 {
+		return 0
+	}
+	return a.RestoreHP(math.Max(1, math.Floor(a.Stats.Get("HpMax")*regen)))
+}
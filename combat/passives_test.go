@@ -0,0 +1,75 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestPassivesStackFromAllSources(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.Equipped["Accessory1"] = 24 //Troll Ring
+	hero.LearnPassives([]string{world.PassiveRegen})
+	hero.AddStatus(world.StatusRegen)
+
+	want := 3 * world.PassivesDB[world.PassiveRegen].Value
+	if got := hero.PassiveValue(world.PassiveRegen); got != want {
+		t.Errorf("expected Regen %v, got %v", want, got)
+	}
+
+	hero.Stats.Set("HpNow", 20)
+	if hp := hero.Regenerate(); hp != 6 {
+		t.Errorf("expected 6 HP regenerated, got %v", hp)
+	}
+}
+
+func TestImmunityBlocksStatus(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.Equipped["Accessory2"] = 28 //Serpent Band
+
+	if hero.AddStatus(world.StatusPoison) || hero.HasStatus(world.StatusPoison) {
+		t.Errorf("Serpent Band should prevent poison")
+	}
+	if !hero.AddStatus(world.StatusBlind) {
+		t.Errorf("Serpent Band only protects from poison")
+	}
+}
+
+func TestRegenIsNotAnAilment(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.AddStatus(world.StatusRegen)
+	if hero.HasAnyStatus() {
+		t.Errorf("Regen status must not make Actor a cure target")
+	}
+}
+
+func TestMpCostAndXPMultiplier(t *testing.T) {
+	mage := ActorFromDef(MageDef)
+	if mage.MpCost(8) != 8 || mage.XPMultiplier() != 1 {
+		t.Fatalf("no passives should change nothing")
+	}
+
+	mage.Equipped["Accessory1"] = 25 //Sage's Pendant
+	mage.Equipped["Accessory2"] = 27 //Scholar's Charm
+	if got := mage.MpCost(8); got != 6 {
+		t.Errorf("expected MP cost 6, got %v", got)
+	}
+	if mage.XPMultiplier() != 2 {
+		t.Errorf("Scholar's Charm should double XP")
+	}
+}
+
+func TestApplyLevelLearnsPassives(t *testing.T) {
+	thief := ActorFromDef(ThiefDef)
+	thief.ApplyLevel(LevelUp{
+		Level:   1,
+		Actions: map[string][]string{ActionPassive: {world.PassiveStealBonus}},
+	})
+
+	if !thief.HasPassive(world.PassiveStealBonus) {
+		t.Errorf("expected StealBonus to be learned")
+	}
+	if thief.HasAction(ActionPassive) {
+		t.Errorf("passives must not show up as menu actions")
+	}
+}
@@ -0,0 +1,115 @@
+package combat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//CombatData what a won battle gives out before RewardRules apply
+type CombatData struct {
+	XP, Gold, AP float64
+	Loot         []world.ItemIndex
+	Fought       []string //ids of party members that took part, nil means all
+	NoDamage     bool     //no party member lost HP
+}
+
+//RewardRules how CombatData is shared by the party, see CalcRewards
+type RewardRules struct {
+	SplitXP        bool    //XP is divided between members earning a full share
+	KOShare        float64 //share of XP for members KO'd at the end of battle
+	ReserveShare   float64 //share of XP for members that didn't fight
+	XPMultiplier   float64 //e.g. difficulty, 0 means 1
+	GoldMultiplier float64 //e.g. difficulty, 0 means 1
+	NoDamageBonus  float64 //extra share of XP & Gold if nobody got hurt
+}
+
+var DefaultRewardRules = RewardRules{
+	ReserveShare:   0.5,
+	XPMultiplier:   1,
+	GoldMultiplier: 1,
+	NoDamageBonus:  0.2,
+}
+
+//Rewards result of CalcRewards, notes tell which rules changed them
+type Rewards struct {
+	XP        map[string]float64 //actor id -> XP, Actor.XPMultiplier included
+	Gold      float64
+	XPNotes   []string
+	GoldNotes []string
+}
+
+//MaxXP highest XP earned by a party member
+func (r Rewards) MaxXP() float64 {
+	max := 0.0
+	for _, xp := range r.XP {
+		max = math.Max(max, xp)
+	}
+	return max
+}
+
+//CalcRewards applies rules to data for every party member, party is left unchanged
+func CalcRewards(rules RewardRules, data CombatData, party []*Actor) Rewards {
+	r := Rewards{XP: make(map[string]float64)}
+	xp, gold := data.XP, data.Gold
+
+	if rules.XPMultiplier != 0 && rules.XPMultiplier != 1 {
+		xp *= rules.XPMultiplier
+		r.XPNotes = append(r.XPNotes, fmt.Sprintf("XP x%v", rules.XPMultiplier))
+	}
+	if rules.GoldMultiplier != 0 && rules.GoldMultiplier != 1 {
+		gold *= rules.GoldMultiplier
+		r.GoldNotes = append(r.GoldNotes, fmt.Sprintf("Gold x%v", rules.GoldMultiplier))
+	}
+	if data.NoDamage && rules.NoDamageBonus > 0 {
+		note := fmt.Sprintf("No damage +%.0f%%", rules.NoDamageBonus*100)
+		xp *= 1 + rules.NoDamageBonus
+		gold *= 1 + rules.NoDamageBonus
+		r.XPNotes = append(r.XPNotes, note)
+		r.GoldNotes = append(r.GoldNotes, note)
+	}
+
+	shares := make(map[string]float64)
+	fighters, goldBonus := 0, 0.0
+	var koed, reserve int
+	for _, a := range party {
+		switch {
+		case !hasFought(data, a.Id):
+			shares[a.Id] = rules.ReserveShare
+			reserve++
+		case a.IsKOed():
+			shares[a.Id] = rules.KOShare
+			koed++
+		default:
+			shares[a.Id] = 1
+			fighters++
+			goldBonus += a.PassiveValue(world.PassiveGoldBonus)
+		}
+	}
+
+	if goldBonus > 0 {
+		gold *= 1 + goldBonus
+		r.GoldNotes = append(r.GoldNotes, fmt.Sprintf("%s +%.0f%%", world.PassivesDB[world.PassiveGoldBonus].Name, goldBonus*100))
+	}
+	if rules.SplitXP && fighters > 1 {
+		xp /= float64(fighters)
+		r.XPNotes = append(r.XPNotes, fmt.Sprintf("Split by %d", fighters))
+	}
+	if koed > 0 {
+		r.XPNotes = append(r.XPNotes, fmt.Sprintf("KO'd %.0f%%", rules.KOShare*100))
+	}
+	if reserve > 0 {
+		r.XPNotes = append(r.XPNotes, fmt.Sprintf("Reserve %.0f%%", rules.ReserveShare*100))
+	}
+
+	for _, a := range party {
+		r.XP[a.Id] = math.Floor(xp * shares[a.Id] * a.XPMultiplier())
+	}
+	r.Gold = math.Floor(gold)
+	return r
+}
+
+func hasFought(data CombatData, actorId string) bool {
+	return data.Fought == nil || hasString(data.Fought, actorId)
+}
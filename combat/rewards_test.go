@@ -0,0 +1,59 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func testParty() []*Actor {
+	hero, mage, thief := ActorFromDef(HeroDef), ActorFromDef(MageDef), ActorFromDef(ThiefDef)
+	return []*Actor{&hero, &mage, &thief}
+}
+
+func TestCalcRewardsFullXP(t *testing.T) {
+	party := testParty()
+	party[1].Stats.Set("HpNow", 0)
+	data := CombatData{XP: 100, Gold: 50, Fought: []string{"hero", "mage"}}
+
+	r := CalcRewards(DefaultRewardRules, data, party)
+	want := map[string]float64{"hero": 100, "mage": 0, "thief": 50}
+	for id, xp := range want {
+		if r.XP[id] != xp {
+			t.Errorf("%s: got %v XP want %v", id, r.XP[id], xp)
+		}
+	}
+	if r.Gold != 50 || len(r.GoldNotes) != 0 {
+		t.Errorf("gold should be unchanged, got %v %v", r.Gold, r.GoldNotes)
+	}
+	if len(r.XPNotes) != 2 {
+		t.Errorf("expected KO and reserve notes, got %v", r.XPNotes)
+	}
+	if party[0].XP != 0 {
+		t.Errorf("CalcRewards must not change the party")
+	}
+}
+
+func TestCalcRewardsSplitAndBonuses(t *testing.T) {
+	party := testParty()
+	party[0].Equipped["Accessory1"] = 27 //Scholar's Charm, DoubleXP
+	party[2].Equipped["Accessory1"] = 29 //Lucky Coin
+	rules := RewardRules{SplitXP: true, XPMultiplier: 1.5, GoldMultiplier: 2, NoDamageBonus: 0.2}
+	data := CombatData{XP: 100, Gold: 10, NoDamage: true}
+
+	r := CalcRewards(rules, data, party)
+	//100 * 1.5 * 1.2 / 3
+	if r.XP["mage"] != 60 || r.XP["hero"] != 120 {
+		t.Errorf("expected 60 XP, doubled for hero, got %v", r.XP)
+	}
+	//10 * 2 * 1.2 * 1.25
+	if r.Gold != 30 {
+		t.Errorf("expected 30 gold, got %v %v", r.Gold, r.GoldNotes)
+	}
+	if r.MaxXP() != 120 {
+		t.Errorf("got MaxXP %v", r.MaxXP())
+	}
+	if world.PassivesDB[world.PassiveGoldBonus].Value != 0.25 {
+		t.Errorf("test assumes Gold Finder +25%%")
+	}
+}
@@ -0,0 +1,68 @@
+package combat
+
+import "github.com/steelx/go-rpg-cgm/world"
+
+//Row where an Actor stands in its side's formation
+type Row int
+
+const (
+	FrontRow Row = iota
+	BackRow
+)
+
+//BackRowDamage melee damage dealt from and taken in the back row
+const BackRowDamage = 0.5
+
+func (r Row) String() string {
+	if r == BackRow {
+		return "Back"
+	}
+	return "Front"
+}
+
+func (r Row) Toggle() Row {
+	if r == BackRow {
+		return FrontRow
+	}
+	return BackRow
+}
+
+//EffectiveRow row of a, which is exposed as the front row once
+//nobody on its side stands in the front row
+func EffectiveRow(a *Actor, side []*Actor) Row {
+	if a.Row == FrontRow {
+		return FrontRow
+	}
+	for _, v := range side {
+		if v.Row == FrontRow && !v.IsKOed() {
+			return BackRow
+		}
+	}
+	return FrontRow
+}
+
+//RowMultiplier melee damage multiplier from the rows of attacker and target.
+//Ranged weapons ignore rows, spells never call it
+func RowMultiplier(attacker *Actor, attackerSide []*Actor, target *Actor, targetSide []*Actor) float64 {
+	if attacker.IsRanged() {
+		return 1
+	}
+	multiplier := 1.0
+	if EffectiveRow(attacker, attackerSide) == BackRow {
+		multiplier *= BackRowDamage
+	}
+	if EffectiveRow(target, targetSide) == BackRow {
+		multiplier *= BackRowDamage
+	}
+	return multiplier
+}
+
+//IsRanged an equipped item is a ranged weapon e.g. a bow
+func (a Actor) IsRanged() bool {
+	for _, itemId := range a.Equipped {
+		if itemId != 0 && world.ItemsDB[itemId].Ranged {
+			return true
+		}
+	}
+	return false
+}
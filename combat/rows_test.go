@@ -0,0 +1,41 @@
+package combat
+
+import "testing"
+
+func TestRowMultiplier(t *testing.T) {
+	hero, mage := ActorFromDef(HeroDef), ActorFromDef(MageDef)
+	goblin, dragon := ActorFromDef(GoblinDef), ActorFromDef(DragonDef)
+	mage.Row, dragon.Row = BackRow, BackRow
+	party := []*Actor{&hero, &mage}
+	enemies := []*Actor{&goblin, &dragon}
+
+	cases := []struct {
+		attacker, target *Actor
+		want             float64
+	}{
+		{&hero, &goblin, 1},
+		{&hero, &dragon, 0.5},
+		{&mage, &goblin, 0.5},
+		{&mage, &dragon, 0.25},
+		{&goblin, &mage, 0.5},
+	}
+	for _, c := range cases {
+		attackerSide, targetSide := party, enemies
+		if c.attacker == &goblin {
+			attackerSide, targetSide = enemies, party
+		}
+		if got := RowMultiplier(c.attacker, attackerSide, c.target, targetSide); got != c.want {
+			t.Errorf("%s -> %s: got %v want %v", c.attacker.Id, c.target.Id, got, c.want)
+		}
+	}
+
+	goblin.Stats.Set("HpNow", 0)
+	if EffectiveRow(&dragon, enemies) != FrontRow || RowMultiplier(&hero, party, &dragon, enemies) != 1 {
+		t.Errorf("back row must be exposed once the front row is down")
+	}
+
+	mage.Equipped["Weapon"] = 30
+	if !mage.IsRanged() || RowMultiplier(&mage, party, &goblin, enemies) != 1 {
+		t.Errorf("ranged weapons ignore rows")
+	}
+}
@@ -0,0 +1,124 @@
+package combat
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//RuleProfile named set of combat constants e.g. "classic" or "hard",
+//game_map.FormulaT reads them, see ParseRuleProfiles for the data file
+type RuleProfile struct {
+	Name, Description string
+	Magic             string  //MagicFormulas key
+	HitBonus          float64 //added to the HitChance of party members
+	CritBonus         float64 //added to the CritChance of party members
+	EnemyHitBonus     float64 //added to the HitChance of enemies
+	EnemyDamage       float64 //melee damage dealt by enemies is multiplied by it
+	FleeChance        float64
+	FleeSpeedBonus    float64 //added to FleeChance when faster than the enemies, taken when slower
+	StealChance       float64 //used when the thief isn't of a higher level than the target
+	StealMin          float64
+	StealMax          float64
+}
+
+//DefaultRuleProfile used when no profile is chosen, or a column is missing from the data file
+var DefaultRuleProfile = RuleProfile{
+	Name:           "standard",
+	Magic:          RevisedMagic.Name,
+	EnemyDamage:    1,
+	FleeChance:     0.35,
+	FleeSpeedBonus: 0.15,
+	StealChance:    0.5,
+	StealMin:       0.05,
+	StealMax:       0.95,
+}
+
+//FleeChance of runner getting away, better when faster than
+//the enemies on average. Escape items e.g. Smoke Bomb don't roll it
+func FleeChance(rules RuleProfile, runner *Actor, enemies []*Actor) float64 {
+	if runner.Stats.Get("Speed") > averageSpeed(enemies) {
+		return rules.FleeChance + rules.FleeSpeedBonus
+	}
+	return rules.FleeChance - rules.FleeSpeedBonus
+}
+
+//MagicFormulas RuleProfile.Magic -> MagicFormula
+var MagicFormulas = map[string]MagicFormula{
+	ClassicMagic.Name: ClassicMagic,
+	RevisedMagic.Name: RevisedMagic,
+}
+
+//ParseRuleProfiles reads a csv file, a header naming RuleProfile fields
+//and then a row per profile, kept in file order
+/*csv file:
+Name,Magic,EnemyDamage,FleeChance
+classic,Classic,1,0.35
+hard,Revised,1.25,0.25
+*/
+func ParseRuleProfiles(r io.Reader) ([]RuleProfile, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("rule profiles: no header")
+	}
+
+	header := rows[0]
+	var profiles []RuleProfile
+	for _, row := range rows[1:] {
+		p := DefaultRuleProfile
+		for i, column := range header {
+			if err := p.set(strings.TrimSpace(column), strings.TrimSpace(row[i])); err != nil {
+				return nil, err
+			}
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("rule profiles: row without a Name %v", row)
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+func (p *RuleProfile) set(column, value string) error {
+	switch column {
+	case "Name":
+		p.Name = value
+		return nil
+	case "Description":
+		p.Description = value
+		return nil
+	case "Magic":
+		if _, ok := MagicFormulas[value]; !ok {
+			return fmt.Errorf("rule profiles: %s unknown Magic '%s'", p.Name, value)
+		}
+		p.Magic = value
+		return nil
+	}
+
+	fields := map[string]*float64{
+		"HitBonus":       &p.HitBonus,
+		"CritBonus":      &p.CritBonus,
+		"EnemyHitBonus":  &p.EnemyHitBonus,
+		"EnemyDamage":    &p.EnemyDamage,
+		"FleeChance":     &p.FleeChance,
+		"FleeSpeedBonus": &p.FleeSpeedBonus,
+		"StealChance":    &p.StealChance,
+		"StealMin":       &p.StealMin,
+		"StealMax":       &p.StealMax,
+	}
+	field, ok := fields[column]
+	if !ok {
+		return fmt.Errorf("rule profiles: unknown column '%s'", column)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("rule profiles: %s %s: %v", p.Name, column, err)
+	}
+	*field = v
+	return nil
+}
@@ -0,0 +1,71 @@
+package combat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/resources"
+)
+
+func TestParseRuleProfiles(t *testing.T) {
+	data := "Name,Magic,EnemyDamage,FleeChance\n" +
+		"classic,Classic,1,0.35\n" +
+		"hard,Revised,1.25,0.25\n"
+	profiles, err := ParseRuleProfiles(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "classic" || profiles[1].Name != "hard" {
+		t.Fatalf("profiles should keep file order, got %v", profiles)
+	}
+	hard := profiles[1]
+	if hard.EnemyDamage != 1.25 || hard.FleeChance != 0.25 || hard.Magic != RevisedMagic.Name {
+		t.Errorf("got %+v", hard)
+	}
+	if hard.StealChance != DefaultRuleProfile.StealChance {
+		t.Errorf("missing columns keep the default, got %v", hard.StealChance)
+	}
+
+	for _, bad := range []string{
+		"Name,Luck\nclassic,1\n",
+		"Name,Magic\nclassic,Alchemy\n",
+		"Name,FleeChance\nclassic,often\n",
+		"Name,FleeChance\n,0.3\n",
+	} {
+		if _, err := ParseRuleProfiles(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q should not parse", bad)
+		}
+	}
+}
+
+func TestRuleProfilesFile(t *testing.T) {
+	f, err := resources.FS.Open("combat_rules.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	profiles, err := ParseRuleProfiles(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard := profiles[0]
+	standard.Description = ""
+	if standard != DefaultRuleProfile {
+		t.Errorf("the first profile should match DefaultRuleProfile, got %+v", profiles[0])
+	}
+}
+
+func TestFleeChance(t *testing.T) {
+	thief, goblin := ActorFromDef(ThiefDef), ActorFromDef(GoblinDef)
+	rules := DefaultRuleProfile
+	enemies := []*Actor{&goblin}
+
+	if got := FleeChance(rules, &thief, enemies); got != rules.FleeChance+rules.FleeSpeedBonus {
+		t.Errorf("the thief outruns a goblin, got %v", got)
+	}
+	goblin.Stats.Set("Speed", 20)
+	if got := FleeChance(rules, &thief, enemies); got != rules.FleeChance-rules.FleeSpeedBonus {
+		t.Errorf("got %v", got)
+	}
+}
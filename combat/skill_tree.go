@@ -0,0 +1,228 @@
+package combat
+
+import (
+	"fmt"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//SkillPointsPerLevel earned on every level up, spent in SkillTreesDB
+const SkillPointsPerLevel = 1
+
+//RespecGoldPerPoint gold cost of Respec for every spent skill point
+const RespecGoldPerPoint = 50.0
+
+//SkillNode unlocks a stat Modifier, an Ability or both once
+//all Requires nodes of the same tree are unlocked
+type SkillNode struct {
+	Id, Name    string
+	Description string
+	Cost        int
+	Requires    []string        //SkillNode ids
+	Modifier    *world.Modifier //UniqueId must not clash with ItemsDB ids
+	Ability     Ability
+}
+
+type SkillTree struct {
+	Id    string //actor id e.g. "hero"
+	Nodes []SkillNode
+}
+
+func (t SkillTree) Node(nodeId string) (SkillNode, bool) {
+	for _, n := range t.Nodes {
+		if n.Id == nodeId {
+			return n, true
+		}
+	}
+	return SkillNode{}, false
+}
+
+func (a Actor) SkillTree() SkillTree {
+	return SkillTreesDB[a.Id]
+}
+
+func (a Actor) HasSkill(nodeId string) bool {
+	return hasString(a.Skills, nodeId)
+}
+
+//CanUnlockSkill enough SkillPoints and every required node unlocked
+func (a Actor) CanUnlockSkill(nodeId string) bool {
+	node, ok := a.SkillTree().Node(nodeId)
+	if !ok || a.HasSkill(nodeId) || a.SkillPoints < node.Cost {
+		return false
+	}
+	for _, req := range node.Requires {
+		if !a.HasSkill(req) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Actor) UnlockSkill(nodeId string) bool {
+	if !a.CanUnlockSkill(nodeId) {
+		return false
+	}
+	node, _ := a.SkillTree().Node(nodeId)
+	a.SkillPoints -= node.Cost
+	a.Skills = append(a.Skills, nodeId)
+	if node.Modifier != nil {
+		a.Stats.Apply(world.ModSource{Kind: world.SourceSkill, Id: node.Id}, *node.Modifier)
+	}
+	if node.Ability.Id != "" && node.Ability.Action != ActionPassive {
+		a.RefreshSkillAbilities()
+	}
+	return true
+}
+
+func (a Actor) SpentSkillPoints() int {
+	spent := 0
+	tree := a.SkillTree()
+	for _, nodeId := range a.Skills {
+		node, _ := tree.Node(nodeId)
+		spent += node.Cost
+	}
+	return spent
+}
+
+//skillAbilities Magic, Special and Passive abilities of unlocked nodes
+func (a Actor) skillAbilities() []Ability {
+	var list []Ability
+	tree := a.SkillTree()
+	for _, nodeId := range a.Skills {
+		if node, ok := tree.Node(nodeId); ok && node.Ability.Id != "" {
+			list = append(list, node.Ability)
+		}
+	}
+	return list
+}
+
+//skillPassives see ActivePassives
+func (a Actor) skillPassives() []string {
+	var passives []string
+	for _, ab := range a.skillAbilities() {
+		if ab.Action == ActionPassive {
+			passives = append(passives, ab.Id)
+		}
+	}
+	return passives
+}
+
+//RefreshSkillAbilities grants Magic & Special of unlocked nodes,
+//Job and equipment abilities are granted again on top
+func (a *Actor) RefreshSkillAbilities() {
+	a.takeBack(a.LentAbilities, a.lentActions)
+	a.LentAbilities = make(map[string]string)
+	a.lentActions = nil
+
+	a.takeBack(a.JobGranted, a.jobActions)
+	a.JobGranted = make(map[string]string)
+	a.jobActions = nil
+
+	a.takeBack(a.SkillGranted, a.skillActions)
+	a.SkillGranted = make(map[string]string)
+	a.skillActions = nil
+
+	for _, ab := range a.skillAbilities() {
+		a.grant(ab, a.SkillGranted, &a.skillActions)
+	}
+
+	a.RefreshJobAbilities()
+}
+
+//ResetSkills locks every node and refunds the points spent
+func (a *Actor) ResetSkills() {
+	a.Stats.RemoveKind(world.SourceSkill)
+	a.SkillPoints += a.SpentSkillPoints()
+	a.Skills = nil
+	a.RefreshSkillAbilities()
+}
+
+func RespecCost(a Actor) float64 {
+	return float64(a.SpentSkillPoints()) * RespecGoldPerPoint
+}
+
+//Respec pays RespecCost and resets actor's skill tree
+func (w *WorldExtended) Respec(a *Actor) bool {
+	cost := RespecCost(*a)
+	if len(a.Skills) == 0 || w.Gold < cost {
+		return false
+	}
+	w.Gold -= cost
+	a.ResetSkills()
+	return true
+}
+
+//ValidateSkillTrees checks abilities exist, requirements point to nodes
+//of the same tree without cycles, and modifier ids are unique
+func ValidateSkillTrees(trees map[string]SkillTree) []error {
+	var errs []error
+	for treeId, tree := range trees {
+		seen := make(map[string]bool)
+		modIds := make(map[int]string)
+		for _, n := range tree.Nodes {
+			if seen[n.Id] {
+				errs = append(errs, fmt.Errorf("%s: duplicate node '%s'", treeId, n.Id))
+			}
+			seen[n.Id] = true
+
+			if n.Cost <= 0 {
+				errs = append(errs, fmt.Errorf("%s.%s: cost must be positive", treeId, n.Id))
+			}
+			if n.Modifier == nil && n.Ability.Id == "" {
+				errs = append(errs, fmt.Errorf("%s.%s: unlocks nothing", treeId, n.Id))
+			}
+			if n.Ability.Id != "" && !abilityExists(n.Ability) {
+				errs = append(errs, fmt.Errorf("%s.%s: unknown %s '%s'", treeId, n.Id, n.Ability.Action, n.Ability.Id))
+			}
+			if n.Modifier != nil {
+				id := n.Modifier.UniqueId
+				if _, ok := world.ItemsDB[id]; ok {
+					errs = append(errs, fmt.Errorf("%s.%s: modifier id %v clashes with an item", treeId, n.Id, id))
+				}
+				if other, ok := modIds[id]; ok {
+					errs = append(errs, fmt.Errorf("%s.%s: modifier id %v already used by '%s'", treeId, n.Id, id, other))
+				}
+				modIds[id] = n.Id
+			}
+			for _, req := range n.Requires {
+				if _, ok := tree.Node(req); !ok {
+					errs = append(errs, fmt.Errorf("%s.%s: requires unknown node '%s'", treeId, n.Id, req))
+				}
+			}
+		}
+		for _, n := range tree.Nodes {
+			if requiresItself(tree, n.Id, n.Id, make(map[string]bool)) {
+				errs = append(errs, fmt.Errorf("%s.%s: requirement cycle", treeId, n.Id))
+			}
+		}
+	}
+	return errs
+}
+
+func abilityExists(ab Ability) bool {
+	var ok bool
+	switch ab.Action {
+	case ActionMagic:
+		_, ok = world.SpellsDB[ab.Id]
+	case ActionSpecial:
+		_, ok = world.SpecialsDB[ab.Id]
+	case ActionPassive:
+		_, ok = world.PassivesDB[ab.Id]
+	}
+	return ok
+}
+
+func requiresItself(tree SkillTree, start, nodeId string, visited map[string]bool) bool {
+	if visited[nodeId] {
+		return false
+	}
+	visited[nodeId] = true
+	node, _ := tree.Node(nodeId)
+	for _, req := range node.Requires {
+		if req == start || requiresItself(tree, start, req, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package combat
+
+import "github.com/steelx/go-rpg-cgm/world"
+
+//SkillTreesDB actor id -> SkillTree, checked by ValidateSkillTrees
+var SkillTreesDB = map[string]SkillTree{
+	"hero":  HeroSkillTree,
+	"mage":  MageSkillTree,
+	"thief": ThiefSkillTree,
+}
+
+var HeroSkillTree = SkillTree{
+	Id: "hero",
+	Nodes: []SkillNode{
+		{
+			Id: "power1", Name: "Power I", Cost: 1,
+			Description: "Strength +3",
+			Modifier:    &world.Modifier{Name: "Power I", UniqueId: 1001, Mod: world.Mod{Add: world.BaseStats{Strength: 3}}},
+		},
+		{
+			Id: "vitality", Name: "Vitality", Cost: 1,
+			Description: "HP +25",
+			Modifier:    &world.Modifier{Name: "Vitality", UniqueId: 1002, Mod: world.Mod{Add: world.BaseStats{HpMax: 25}}},
+		},
+		{
+			Id: "power2", Name: "Power II", Cost: 2,
+			Description: "Strength +5",
+			Requires:    []string{"power1"},
+			Modifier:    &world.Modifier{Name: "Power II", UniqueId: 1003, Mod: world.Mod{Add: world.BaseStats{Strength: 5}}},
+		},
+		{
+			Id: "slash", Name: "Slash", Cost: 2,
+			Description: "Learn Slash early",
+			Requires:    []string{"power1"},
+			Ability:     Ability{Action: ActionSpecial, Id: world.SpecialSlash},
+		},
+		{
+			Id: "riposte", Name: "Riposte", Cost: 3,
+			Description: "Counter attacks more often",
+			Requires:    []string{"power2", "vitality"},
+			Ability:     Ability{Action: ActionPassive, Id: world.PassiveCounterBoost},
+		},
+	},
+}
+
+var MageSkillTree = SkillTree{
+	Id: "mage",
+	Nodes: []SkillNode{
+		{
+			Id: "mind1", Name: "Mind I", Cost: 1,
+			Description: "Intelligence +3",
+			Modifier:    &world.Modifier{Name: "Mind I", UniqueId: 1001, Mod: world.Mod{Add: world.BaseStats{Intelligence: 3}}},
+		},
+		{
+			Id: "focus", Name: "Focus", Cost: 1,
+			Description: "MP +10",
+			Modifier:    &world.Modifier{Name: "Focus", UniqueId: 1002, Mod: world.Mod{Add: world.BaseStats{MpMax: 10}}},
+		},
+		{
+			Id: "mind2", Name: "Mind II", Cost: 2,
+			Description: "Intelligence +5",
+			Requires:    []string{"mind1"},
+			Modifier:    &world.Modifier{Name: "Mind II", UniqueId: 1003, Mod: world.Mod{Add: world.BaseStats{Intelligence: 5}}},
+		},
+		{
+			Id: "life", Name: "Life", Cost: 2,
+			Description: "Learn Life",
+			Requires:    []string{"focus"},
+			Ability:     Ability{Action: ActionMagic, Id: world.SpellLife},
+		},
+		{
+			Id: "economy", Name: "Economy", Cost: 3,
+			Description: "Spells cost less MP",
+			Requires:    []string{"mind2", "focus"},
+			Ability:     Ability{Action: ActionPassive, Id: world.PassiveMpSaver},
+		},
+	},
+}
+
+var ThiefSkillTree = SkillTree{
+	Id: "thief",
+	Nodes: []SkillNode{
+		{
+			Id: "agility1", Name: "Agility I", Cost: 1,
+			Description: "Speed +3",
+			Modifier:    &world.Modifier{Name: "Agility I", UniqueId: 1001, Mod: world.Mod{Add: world.BaseStats{Speed: 3}}},
+		},
+		{
+			Id: "nimble", Name: "Nimble", Cost: 1,
+			Description: "Defense +2",
+			Modifier:    &world.Modifier{Name: "Nimble", UniqueId: 1002, Mod: world.Mod{Add: world.BaseStats{Defense: 2}}},
+		},
+		{
+			Id: "agility2", Name: "Agility II", Cost: 2,
+			Description: "Speed +5",
+			Requires:    []string{"agility1"},
+			Modifier:    &world.Modifier{Name: "Agility II", UniqueId: 1003, Mod: world.Mod{Add: world.BaseStats{Speed: 5}}},
+		},
+		{
+			Id: "quickdraw", Name: "Quick Draw", Cost: 2,
+			Description: "Act first in combat",
+			Requires:    []string{"agility1"},
+			Ability:     Ability{Action: ActionPassive, Id: world.PassiveFirstStrike},
+		},
+		{
+			Id: "pickpocket", Name: "Pickpocket", Cost: 3,
+			Description: "Steal succeeds more often",
+			Requires:    []string{"agility2", "nimble"},
+			Ability:     Ability{Action: ActionPassive, Id: world.PassiveStealBonus},
+		},
+	},
+}
@@ -0,0 +1,94 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func TestSkillTreesAreValid(t *testing.T) {
+	for _, err := range ValidateSkillTrees(SkillTreesDB) {
+		t.Error(err)
+	}
+}
+
+func TestValidateSkillTreesReportsErrors(t *testing.T) {
+	trees := map[string]SkillTree{
+		"broken": {Id: "broken", Nodes: []SkillNode{
+			{Id: "a", Cost: 1, Requires: []string{"b"}, Ability: Ability{Action: ActionMagic, Id: "Meteor"}},
+			{Id: "b", Cost: 1, Requires: []string{"a", "c"}, Modifier: &world.Modifier{UniqueId: 1}},
+		}},
+	}
+	//unknown spell, unknown node "c", item id clash, 2 cycles
+	if errs := ValidateSkillTrees(trees); len(errs) != 5 {
+		t.Errorf("expected 5 errors, got %v", errs)
+	}
+}
+
+func TestUnlockSkill(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	hero.SkillPoints = 3
+	strength := hero.Stats.Get("Strength")
+
+	if hero.UnlockSkill("slash") {
+		t.Fatalf("slash requires power1")
+	}
+	if !hero.UnlockSkill("power1") || hero.Stats.Get("Strength") != strength+3 {
+		t.Fatalf("power1 should add 3 Strength, got %v", hero.Stats.Get("Strength"))
+	}
+	if !hero.UnlockSkill("slash") || !hasString(hero.Special, world.SpecialSlash) || !hero.HasAction(ActionSpecial) {
+		t.Errorf("slash should unlock Slash, got %v %v", hero.Special, hero.Actions)
+	}
+	if hero.SkillPoints != 0 || hero.UnlockSkill("vitality") {
+		t.Errorf("no points left, got %v", hero.SkillPoints)
+	}
+}
+
+func TestRespec(t *testing.T) {
+	w := WorldExtendedCreate()
+	w.Party.Add(ActorFromDef(HeroDef))
+	hero := w.Party.Members["hero"]
+	hero.SkillPoints = 3
+	strength := hero.Stats.Get("Strength")
+	hero.UnlockSkill("power1")
+	hero.UnlockSkill("slash")
+
+	w.Gold = RespecCost(*hero) - 1
+	if w.Respec(hero) {
+		t.Fatalf("respec should need %v gold", RespecCost(*hero))
+	}
+
+	w.Gold = RespecCost(*hero)
+	if !w.Respec(hero) {
+		t.Fatalf("expected respec")
+	}
+	if w.Gold != 0 || hero.SkillPoints != 3 || len(hero.Skills) != 0 {
+		t.Errorf("expected 3 points refunded for all gold, got %v points %v gold", hero.SkillPoints, w.Gold)
+	}
+	//the hero knows Slash from the start, only the menu action came from the skill
+	if hero.Stats.Get("Strength") != strength || !hasString(hero.Special, world.SpecialSlash) || hero.HasAction(ActionSpecial) {
+		t.Errorf("respec should take back skills, got %v %v", hero.Special, hero.Actions)
+	}
+}
+
+func TestGrantUnlocksActionOfKnownAbility(t *testing.T) {
+	hero := ActorFromDef(HeroDef)
+	if !hasString(hero.Special, world.SpecialSlash) || hero.HasAction(ActionSpecial) {
+		t.Fatalf("the hero knows Slash but can't use it before level 5, got %v %v", hero.Special, hero.Actions)
+	}
+
+	hero.SkillPoints = 3
+	hero.UnlockSkill("power1")
+	if !hero.UnlockSkill("slash") || !hero.HasAction(ActionSpecial) {
+		t.Errorf("slash node should unlock the Special menu, got %v", hero.Actions)
+	}
+	hero.ResetSkills()
+	if !hasString(hero.Special, world.SpecialSlash) || hero.HasAction(ActionSpecial) {
+		t.Errorf("reset should lock the menu again and keep Slash, got %v %v", hero.Special, hero.Actions)
+	}
+
+	mage := ActorFromDef(MageDef)
+	if !hero.ChangeJob(JobMage) || !mage.HasAction(ActionMagic) || !hero.HasAction(ActionMagic) {
+		t.Errorf("Mage job level 1 should unlock the Magic menu, got %v %v", mage.Actions, hero.Actions)
+	}
+}
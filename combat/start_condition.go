@@ -0,0 +1,146 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//StartCondition how a battle opens, see RollStartCondition
+type StartCondition int
+
+const (
+	StartNormal     StartCondition = iota
+	StartPreemptive                //the party acts first
+	StartBackAttack                //the party is caught from behind, acts later and stands on the other side
+	StartAmbush                    //the enemies act first
+)
+
+func (s StartCondition) String() string {
+	switch s {
+	case StartPreemptive:
+		return "Preemptive Strike!"
+	case StartBackAttack:
+		return "Back Attack!"
+	case StartAmbush:
+		return "Ambush!"
+	}
+	return ""
+}
+
+//EncounterTrigger how the party ran into the enemies
+type EncounterTrigger int
+
+const (
+	TriggerRandom   EncounterTrigger = iota //random encounter while exploring
+	TriggerParty                            //the party engaged the enemies e.g. arena rounds
+	TriggerEnemy                            //the enemies engaged the party e.g. caught while fleeing
+	TriggerScripted                         //story fights, always StartNormal unless forced by the CombatDef
+)
+
+//BackAttackDelay extra share of time points for the party's first turns on a back attack
+const BackAttackDelay = 0.5
+
+//StartRules base chances of each StartCondition, see StartChances
+type StartRules struct {
+	Preemptive, BackAttack, Ambush float64
+	FirstStrikeBonus               float64 //added for every member of a side with PassiveFirstStrike
+}
+
+var DefaultStartRules = StartRules{
+	Preemptive:       0.08,
+	BackAttack:       0.05,
+	Ambush:           0.04,
+	FirstStrikeBonus: 0.2,
+}
+
+//StartChances chance of each StartCondition, the rest is StartNormal.
+//A faster side is more likely to get the upper hand
+func StartChances(rules StartRules, trigger EncounterTrigger, party, enemies []*Actor) map[StartCondition]float64 {
+	chances := make(map[StartCondition]float64)
+	if trigger == TriggerScripted {
+		return chances
+	}
+
+	speedRatio := 1.0
+	if partySpeed, enemySpeed := averageSpeed(party), averageSpeed(enemies); partySpeed > 0 && enemySpeed > 0 {
+		speedRatio = partySpeed / enemySpeed
+	}
+	preemptive := rules.Preemptive*speedRatio + rules.FirstStrikeBonus*countPassive(party, world.PassiveFirstStrike)
+	backAttack := rules.BackAttack / speedRatio
+	ambush := rules.Ambush/speedRatio + rules.FirstStrikeBonus*countPassive(enemies, world.PassiveFirstStrike)
+
+	switch trigger {
+	case TriggerParty:
+		preemptive *= 2
+		backAttack, ambush = 0, 0
+	case TriggerEnemy:
+		preemptive = 0
+		backAttack *= 2
+		ambush *= 2
+	}
+
+	//each chance is taken from what the previous ones left
+	left := 1.0
+	for _, v := range []struct {
+		condition StartCondition
+		chance    float64
+	}{
+		{StartPreemptive, preemptive},
+		{StartBackAttack, backAttack},
+		{StartAmbush, ambush},
+	} {
+		chance := math.Min(math.Max(v.chance, 0), left)
+		chances[v.condition] = chance
+		left -= chance
+	}
+	return chances
+}
+
+//RollStartCondition picks a StartCondition from StartChances, roll is in [0, 1)
+func RollStartCondition(rules StartRules, trigger EncounterTrigger, party, enemies []*Actor, roll float64) StartCondition {
+	chances := StartChances(rules, trigger, party, enemies)
+	for _, condition := range []StartCondition{StartPreemptive, StartBackAttack, StartAmbush} {
+		if roll < chances[condition] {
+			return condition
+		}
+		roll -= chances[condition]
+	}
+	return StartNormal
+}
+
+//FirstTurnTimePoints time points before the first turn of an actor,
+//timePoints is what the actor would usually wait
+func (s StartCondition) FirstTurnTimePoints(isParty bool, timePoints float64) float64 {
+	switch {
+	case s == StartPreemptive && isParty, s == StartAmbush && !isParty:
+		return 0
+	case s == StartBackAttack && isParty:
+		return timePoints * (1 + BackAttackDelay)
+	}
+	return timePoints
+}
+
+func averageSpeed(actors []*Actor) float64 {
+	var total, count float64
+	for _, a := range actors {
+		if !a.IsKOed() {
+			total += a.Stats.Get("Speed")
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / count
+}
+
+func countPassive(actors []*Actor, passiveId string) float64 {
+	count := 0.0
+	for _, a := range actors {
+		if !a.IsKOed() && a.HasPassive(passiveId) {
+			count++
+		}
+	}
+	return count
+}
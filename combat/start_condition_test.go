@@ -0,0 +1,85 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func setSpeed(speed float64, actors []*Actor) {
+	for _, a := range actors {
+		a.Stats.Set("Speed", speed)
+	}
+}
+
+func TestStartChances(t *testing.T) {
+	party := testParty()
+	goblin := ActorFromDef(GoblinDef)
+	enemies := []*Actor{&goblin}
+	setSpeed(10, append(party, enemies...))
+
+	even := StartChances(DefaultStartRules, TriggerRandom, party, enemies)
+	if even[StartPreemptive] != DefaultStartRules.Preemptive || even[StartAmbush] != DefaultStartRules.Ambush {
+		t.Errorf("same speed keeps the base chances, got %v", even)
+	}
+
+	goblin.Stats.Set("Speed", party[0].Stats.Get("Speed")*2)
+	slow := StartChances(DefaultStartRules, TriggerRandom, party, enemies)
+	if slow[StartPreemptive] >= even[StartPreemptive] || slow[StartBackAttack] <= even[StartBackAttack] {
+		t.Errorf("faster enemies get the upper hand, got %v", slow)
+	}
+
+	engaged := StartChances(DefaultStartRules, TriggerParty, party, enemies)
+	if engaged[StartBackAttack] != 0 || engaged[StartAmbush] != 0 {
+		t.Errorf("the party can't be caught when it engages, got %v", engaged)
+	}
+	if c := StartChances(DefaultStartRules, TriggerEnemy, party, enemies); c[StartPreemptive] != 0 {
+		t.Errorf("the party can't strike first when engaged, got %v", c)
+	}
+	for _, chance := range StartChances(DefaultStartRules, TriggerScripted, party, enemies) {
+		if chance != 0 {
+			t.Errorf("scripted fights are never rolled")
+		}
+	}
+
+	for _, a := range party {
+		a.LearnPassives([]string{world.PassiveFirstStrike})
+	}
+	rules := DefaultStartRules
+	rules.FirstStrikeBonus = 1
+	c := StartChances(rules, TriggerRandom, party, enemies)
+	if c[StartPreemptive] != 1 || c[StartBackAttack] != 0 || c[StartAmbush] != 0 {
+		t.Errorf("chances can't add up past 1, got %v", c)
+	}
+}
+
+func TestRollStartCondition(t *testing.T) {
+	party := testParty()
+	goblin := ActorFromDef(GoblinDef)
+	enemies := []*Actor{&goblin}
+	setSpeed(10, append(party, enemies...))
+	rules := StartRules{Preemptive: 0.1, BackAttack: 0.1, Ambush: 0.1}
+
+	for roll, want := range map[float64]StartCondition{
+		0.05: StartPreemptive,
+		0.15: StartBackAttack,
+		0.25: StartAmbush,
+		0.5:  StartNormal,
+	} {
+		if got := RollStartCondition(rules, TriggerRandom, party, enemies, roll); got != want {
+			t.Errorf("roll %v: got %v want %v", roll, got, want)
+		}
+	}
+}
+
+func TestFirstTurnTimePoints(t *testing.T) {
+	if StartPreemptive.FirstTurnTimePoints(true, 200) != 0 || StartPreemptive.FirstTurnTimePoints(false, 200) != 200 {
+		t.Errorf("only the party acts at once on a preemptive strike")
+	}
+	if StartAmbush.FirstTurnTimePoints(false, 200) != 0 || StartAmbush.FirstTurnTimePoints(true, 200) != 200 {
+		t.Errorf("only the enemies act at once on an ambush")
+	}
+	if got := StartBackAttack.FirstTurnTimePoints(true, 200); got != 300 {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package combat
+
+import (
+	"sort"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//TechParticipants members of party performing tech, in tech.Members order.
+//ok is false unless every member is conscious, knows their ability and has its menu action
+//unlocked, has the MP and a turn ready
+func TechParticipants(tech world.Tech, party []*Actor, ready func(*Actor) bool) (participants []*Actor, ok bool) {
+	for _, m := range tech.Members {
+		var member *Actor
+		for _, a := range party {
+			if a.Id == m.ActorId {
+				member = a
+			}
+		}
+		if member == nil || member.IsKOed() || !ready(member) ||
+			!member.HasAction(m.Action) || !member.knows(Ability{Action: m.Action, Id: m.Id}) ||
+			member.Stats.Get("MpNow") < member.MpCost(tech.MpCost) {
+			return nil, false
+		}
+		participants = append(participants, member)
+	}
+	return participants, len(participants) > 1
+}
+
+//AvailableTechs techs actor can start with the rest of party, sorted by name
+func AvailableTechs(actor *Actor, party []*Actor, ready func(*Actor) bool) []world.Tech {
+	var techs []world.Tech
+	for _, tech := range world.TechsDB {
+		participants, ok := TechParticipants(tech, party, ready)
+		if ok && hasActor(participants, actor) {
+			techs = append(techs, tech)
+		}
+	}
+	sort.Slice(techs, func(i, j int) bool {
+		return techs[i].Name < techs[j].Name
+	})
+	return techs
+}
+
+//TechAttack combined attack of the participants, magic members add their
+//Intelligence, the others half their Strength plus Attack
+func TechAttack(tech world.Tech, participants []*Actor) float64 {
+	attack := 0.0
+	for i, a := range participants {
+		if tech.Members[i].Action == ActionMagic {
+			attack += a.Stats.Get("Intelligence")
+		} else {
+			attack += a.Stats.Get("Strength")/2 + a.Stats.Get("Attack")
+		}
+	}
+	return attack * tech.Power
+}
+
+func hasActor(list []*Actor, actor *Actor) bool {
+	for _, a := range list {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
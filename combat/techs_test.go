@@ -0,0 +1,77 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+func testTechParty() []*Actor {
+	party := testParty()
+	party[0].Special = []string{world.SpecialSlash}
+	party[0].UnlockMenuAction(ActionSpecial)
+	party[1].Magic = []string{world.SpellFire, world.SpellBolt}
+	party[2].Special = []string{world.SpecialSteal}
+	for _, a := range party {
+		a.Stats.Set("MpNow", 10)
+	}
+	return party
+}
+
+func techIds(techs []world.Tech) (ids []string) {
+	for _, tech := range techs {
+		ids = append(ids, tech.Id)
+	}
+	return ids
+}
+
+func TestAvailableTechs(t *testing.T) {
+	party := testTechParty()
+	hero, mage := party[0], party[1]
+	all := func(*Actor) bool { return true }
+
+	if got := techIds(AvailableTechs(mage, party, all)); len(got) != 2 || got[0] != world.TechFireSword || got[1] != world.TechThunderRaid {
+		t.Errorf("mage knows Fire and Bolt, not Burn, got %v", got)
+	}
+	if got := techIds(AvailableTechs(hero, party, all)); len(got) != 1 {
+		t.Errorf("got %v", got)
+	}
+
+	notMage := func(a *Actor) bool { return a != mage }
+	if got := AvailableTechs(hero, party, notMage); len(got) != 0 {
+		t.Errorf("the mage has no turn available, got %v", techIds(got))
+	}
+
+	mage.Stats.Set("MpNow", 3)
+	if got := AvailableTechs(hero, party, all); len(got) != 0 {
+		t.Errorf("the mage lacks MP, got %v", techIds(got))
+	}
+	mage.Stats.Set("MpNow", 10)
+	mage.Stats.Set("HpNow", 0)
+	if _, ok := TechParticipants(world.TechsDB[world.TechFireSword], party, all); ok {
+		t.Errorf("KO'd members can't take part")
+	}
+}
+
+func TestTechNeedsUnlockedAction(t *testing.T) {
+	party := testTechParty()
+	hero := party[0]
+	hero.Actions = removeString(hero.Actions, ActionSpecial)
+	tech := world.TechsDB[world.TechFireSword]
+	if _, ok := TechParticipants(tech, party, func(*Actor) bool { return true }); ok {
+		t.Errorf("the hero knows Slash but can't use Special yet, got %v", hero.Actions)
+	}
+}
+
+func TestTechAttack(t *testing.T) {
+	party := testTechParty()
+	tech := world.TechsDB[world.TechFireSword]
+	participants, ok := TechParticipants(tech, party, func(*Actor) bool { return true })
+	if !ok || participants[0] != party[0] || participants[1] != party[1] {
+		t.Fatalf("got %v", partyIds(participants))
+	}
+	//(hero Strength 10 / 2 + mage Intelligence 20) * 1.5
+	if got := TechAttack(tech, participants); got != 37.5 {
+		t.Errorf("got %v", got)
+	}
+}
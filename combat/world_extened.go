@@ -4,16 +4,18 @@ import "github.com/steelx/go-rpg-cgm/world"
 
 type WorldExtended struct {
 	world.World
-	Party *Party
+	Party       *Party
+	RewardRules RewardRules
+	BattleMode  BattleMode
+	Difficulty  string //rule profile name, see game_map.RuleProfiles
 }
 
 func WorldExtendedCreate() *WorldExtended {
-	w := &WorldExtended{}
+	w := &WorldExtended{
+		World:       *world.Create(),
+		RewardRules: DefaultRewardRules,
+		Difficulty:  DefaultRuleProfile.Name,
+	}
 	w.Party = PartyCreate(w)
-	w.Time = 0
-	w.Gold = 0
-	w.Items = make([]world.ItemIndex, 0)
-	w.KeyItems = make([]world.ItemIndex, 0)
-	w.Icons = world.IconsDB
 	return w
 }
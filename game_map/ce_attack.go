@@ -121,7 +121,7 @@ func (c *CEAttack) onFinished() {
 
 //CounterTarget - Decide if the attack is countered.
 func (c *CEAttack) CounterTarget(target *combat.Actor) {
-	countered := Formula.IsCountered(c.Scene, c.owner, target)
+	countered := c.Scene.Formula.IsCountered(c.Scene, c.owner, target)
 	if countered {
 		c.Scene.ApplyCounter(target, c.owner)
 	}
@@ -139,7 +139,7 @@ func (c *CEAttack) DoAttack() {
 func (c *CEAttack) attackTarget(target *combat.Actor) {
 
 	//hit result lets us know the status of this attack
-	damage, hitResult := Formula.MeleeAttack(c.Scene, c.owner, target)
+	damage, hitResult := c.Scene.Formula.MeleeAttack(c.Scene, c.owner, target)
 	entity := c.Scene.ActorCharMap[target].Entity
 
 	if hitResult == HitResultMiss {
@@ -153,7 +153,10 @@ func (c *CEAttack) attackTarget(target *combat.Actor) {
 	if hitResult == HitResultCritical {
 		isCrit = true
 	}
-	c.Scene.ApplyDamage(target, damage, isCrit)
+	if hitResult != HitResultDodge {
+		c.Scene.ApplyElementDamage(target, damage, isCrit, c.owner.AttackElement())
+		c.inflictStatuses(target)
+	}
 
 	//FX
 	pos := entity.GetSelectPosition()
@@ -161,3 +164,14 @@ func (c *CEAttack) attackTarget(target *combat.Actor) {
 	effect := AnimEntityFxCreate(x, y, c.AttackEntityDef, c.AttackEntityDef.Frames)
 	c.Scene.AddEffect(effect)
 }
+
+//inflictStatuses e.g. the Goblin's poison, see combat.Actor.Inflicts,
+//resisted like spells through IsStatusHit
+func (c *CEAttack) inflictStatuses(target *combat.Actor) {
+	hit := func(chance float64) bool {
+		return IsStatusHit(c.Scene, c.owner, target, chance)
+	}
+	for _, status := range combat.InflictStatuses(c.owner, target, hit) {
+		c.Scene.AddTextEffect(target, status, 2)
+	}
+}
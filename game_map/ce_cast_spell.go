@@ -121,7 +121,7 @@ func (c *CECastSpell) DoCast() {
 	c.Scene.AddEffect(effect)
 
 	mpNow := c.owner.Stats.Get("MpNow")
-	cost := c.Spell.MpCost
+	cost := c.owner.MpCost(c.Spell.MpCost)
 	mp := math.Max(mpNow-cost, 0)
 
 	c.owner.Stats.Set("MpNow", mp)
@@ -13,3 +13,24 @@ type CombatEvent interface {
 	Execute(queue *EventQueue)
 	TimePoints(queue *EventQueue) float64
 }
+
+//JointEvent a CombatEvent several actors take part in e.g. CETech,
+//none of them gets a turn until it is over
+type JointEvent interface {
+	Participants() []*combat.Actor
+}
+
+//takesPart owner or one of the participants of a JointEvent
+func takesPart(event CombatEvent, actor *combat.Actor) bool {
+	if event.Owner() == actor {
+		return true
+	}
+	if joint, ok := event.(JointEvent); ok {
+		for _, v := range joint.Participants() {
+			if v == actor {
+				return true
+			}
+		}
+	}
+	return false
+}
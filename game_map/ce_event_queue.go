@@ -15,6 +15,8 @@ import (
 type EventQueue struct {
 	Queue        []CombatEvent
 	CurrentEvent CombatEvent
+	RealTime     bool                   //ATB, countdowns drop with Tick instead of after every event
+	Hold         func(CombatEvent) bool //keeps a due event waiting e.g. a party turn while a menu is open
 }
 
 func EventsQueueCreate() *EventQueue {
@@ -65,12 +67,12 @@ func (q EventQueue) IsEmpty() bool {
 }
 
 func (q EventQueue) ActorHasEvent(actor *combat.Actor) bool {
-	if q.CurrentEvent != nil && q.CurrentEvent.Owner() == actor {
+	if q.CurrentEvent != nil && takesPart(q.CurrentEvent, actor) {
 		return true
 	}
 
 	for _, v := range q.Queue {
-		if v.Owner() == actor {
+		if takesPart(v, actor) {
 			return true
 		}
 	}
@@ -78,6 +80,20 @@ func (q EventQueue) ActorHasEvent(actor *combat.Actor) bool {
 	return false
 }
 
+//TurnReady actor's next event is its turn and, in RealTime mode, it is due
+func (q EventQueue) TurnReady(actor *combat.Actor) bool {
+	if q.CurrentEvent != nil && takesPart(q.CurrentEvent, actor) {
+		return false
+	}
+	for _, v := range q.Queue {
+		if takesPart(v, actor) {
+			_, isTurn := v.(*CETurn)
+			return isTurn && (!q.RealTime || v.CountDown() <= 0)
+		}
+	}
+	return false
+}
+
 func (q *EventQueue) RemoveEventsOwnedBy(actor *combat.Actor) {
 	for i := len(q.Queue) - 1; i >= 0; i-- {
 		v := q.Queue[i]
@@ -126,12 +142,19 @@ func (q *EventQueue) Update() {
 		return
 	} else {
 		// Need to chose an event
-		front := q.Queue[0]
-		q.removeQueAtIndex(0)
+		i := q.nextIndex()
+		if i == -1 {
+			return
+		}
+		front := q.Queue[i]
+		q.removeQueAtIndex(i)
 		front.Execute(q)
 		q.CurrentEvent = front
 	}
 
+	if q.RealTime {
+		return //see Tick
+	}
 	//all the other events countdown reduced by one.
 	for _, v := range q.Queue {
 		//ensure countdown doesnt drop below 0
@@ -139,6 +162,29 @@ func (q *EventQueue) Update() {
 	}
 }
 
+//nextIndex first event to execute, -1 if none is due yet
+func (q EventQueue) nextIndex() int {
+	for i, v := range q.Queue {
+		if q.RealTime && v.CountDown() > 0 {
+			return -1 //the queue is sorted, the rest isn't due either
+		}
+		if q.Hold == nil || !q.Hold(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+//Tick countdowns drop in real time, only in RealTime mode
+func (q *EventQueue) Tick(dt float64) {
+	if !q.RealTime {
+		return
+	}
+	for _, v := range q.Queue {
+		v.CountDownSet(math.Max(0, v.CountDown()-dt*combat.ATBTimePointsPerSecond))
+	}
+}
+
 func (q *EventQueue) Render(win *pixelgl.Window) {
 	yInc := 12.5
 	var width, height float64
@@ -16,9 +16,22 @@ type CEFlee struct {
 	FleeParams CSMoveParams
 	CanFlee    bool
 	Storyboard *Storyboard
+	facing     string //to face the enemies again if fleeing fails
 }
 
 func CEFleeCreate(scene *CombatState, owner *combat.Actor, fleeParams CSMoveParams) *CEFlee {
+	//Scene CanFlee override
+	canFlee := scene.CanFlee && scene.Formula.CanFlee(scene, owner)
+	return ceFleeCreate(scene, owner, fleeParams, canFlee)
+}
+
+//CEEscapeCreate flee which always succeeds e.g. Smoke Bomb,
+//unless the scene does not allow fleeing at all
+func CEEscapeCreate(scene *CombatState, owner *combat.Actor, fleeParams CSMoveParams) *CEFlee {
+	return ceFleeCreate(scene, owner, fleeParams, scene.CanFlee)
+}
+
+func ceFleeCreate(scene *CombatState, owner *combat.Actor, fleeParams CSMoveParams, canFlee bool) *CEFlee {
 	//CSMoveParams{Dir: -1, Distance: 180, Time: 0.6}
 	c := &CEFlee{
 		Scene:      scene,
@@ -26,19 +39,14 @@ func CEFleeCreate(scene *CombatState, owner *combat.Actor, fleeParams CSMovePara
 		Character:  scene.ActorCharMap[owner],
 		FleeParams: fleeParams,
 		name:       fmt.Sprintf("Flee for %s", owner.Name),
+		CanFlee:    canFlee,
 	}
 
-	c.Character.Facing = CharacterFacingDirection[1] //right
+	c.facing = c.Character.Facing
+	c.Character.Facing = fleeFacing(c.Character)
 	c.Character.Controller.Change(csRunanim, csProne, false)
 	var storyboardEvents []interface{}
 
-	//Scene CanFlee override
-	if c.Scene.CanFlee {
-		c.CanFlee = Formula.CanFlee(scene, owner)
-	} else {
-		c.CanFlee = false
-	}
-
 	if c.CanFlee {
 		storyboardEvents = []interface{}{
 			//stateMachine, stateID, ...animID, additionalParams
@@ -107,7 +115,7 @@ func (c CEFlee) TimePoints(queue *EventQueue) float64 {
 }
 
 func (c *CEFlee) OnFleeFail() {
-	c.Character.Facing = CharacterFacingDirection[3]    //left
+	c.Character.Facing = c.facing
 	c.Character.Controller.Change(csStandby, csStandby) //animId
 	c.finished = true
 	c.Scene.HideNotice()
@@ -123,7 +131,7 @@ func (c *CEFlee) DoFleeSuccess() {
 
 		if alive && !isFleer {
 			char := c.Scene.ActorCharMap[v]
-			char.Facing = CharacterFacingDirection[1]
+			char.Facing = fleeFacing(char)
 			char.Controller.Change(csMove, c.FleeParams)
 		}
 	}
@@ -131,3 +139,11 @@ func (c *CEFlee) DoFleeSuccess() {
 	c.Scene.OnFlee()
 	c.Scene.HideNotice()
 }
+
+//fleeFacing away from the enemies, the opposite of facing them in standby
+func fleeFacing(char *Character) string {
+	if char.Facing == CharacterFacingDirection[1] {
+		return CharacterFacingDirection[3] //left, e.g. after a back attack
+	}
+	return CharacterFacingDirection[1] //right
+}
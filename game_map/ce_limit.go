@@ -0,0 +1,160 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/utilz"
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//CELimit unleashes the owner's Limit, see world.LimitsDB
+type CELimit struct {
+	owner       *combat.Actor
+	name        string
+	countDown   float64
+	mIsFinished bool
+
+	Limit           world.SpecialItem
+	Targets         []*combat.Actor
+	Scene           *CombatState
+	Character       *Character
+	Storyboard      *Storyboard
+	AttackEntityDef EntityDefinition
+}
+
+func CELimitCreate(scene *CombatState, owner *combat.Actor, targets []*combat.Actor, limitI interface{}) CombatEvent {
+	limit := reflect.ValueOf(limitI).Interface().(world.SpecialItem)
+	c := &CELimit{
+		owner:           owner,
+		name:            fmt.Sprintf("%s is using Limit : %s", owner.Name, limit.Name),
+		Limit:           limit,
+		Targets:         targets,
+		Scene:           scene,
+		Character:       scene.ActorCharMap[owner],
+		AttackEntityDef: Entities["slash"],
+	}
+
+	c.Character.Controller.Change(csRunanim, csProne, true)
+
+	storyboardEvents := []interface{}{
+		RunFunction(c.ShowNotice),
+		Wait(0.5),
+		RunState(c.Character.Controller, csMove, CSMoveParams{Dir: 3}),
+	}
+	if limit.Action == world.LimitStrike {
+		for i := 0; i < limit.Hits; i++ {
+			storyboardEvents = append(storyboardEvents,
+				RunState(c.Character.Controller, csRunanim, csSpecial, false),
+				Wait(0.3),
+				RunFunction(c.DoStrike),
+			)
+		}
+	} else {
+		storyboardEvents = append(storyboardEvents,
+			RunState(c.Character.Controller, csRunanim, csSpecial, false),
+			Wait(0.2),
+			RunFunction(c.DoHeal),
+			Wait(1),
+		)
+	}
+	storyboardEvents = append(storyboardEvents,
+		RunState(c.Character.Controller, csRunanim, csProne, false),
+		RunFunction(c.Scene.HideNotice),
+		RunState(c.Character.Controller, csMove, CSMoveParams{Dir: -3}),
+		Wait(0.5),
+		RunState(c.Character.Controller, csRunanim, csProne, false),
+		RunFunction(c.OnFinish),
+	)
+
+	c.Storyboard = StoryboardCreate(scene.InternalStack, scene.win, storyboardEvents, false)
+
+	return c
+}
+
+func (c *CELimit) Name() string {
+	return c.name
+}
+
+func (c *CELimit) CountDown() float64 {
+	return c.countDown
+}
+
+func (c *CELimit) CountDownSet(t float64) {
+	c.countDown = t
+}
+
+func (c *CELimit) Owner() *combat.Actor {
+	return c.owner
+}
+
+func (c *CELimit) Update() {
+
+}
+
+func (c *CELimit) IsFinished() bool {
+	return c.mIsFinished
+}
+
+func (c *CELimit) OnFinish() {
+	c.mIsFinished = true
+}
+
+func (c *CELimit) Execute(queue *EventQueue) {
+	c.owner.UseLimit()
+	c.Scene.InternalStack.Push(c.Storyboard)
+}
+
+func (c *CELimit) TimePoints(queue *EventQueue) float64 {
+	speed := c.owner.Stats.Get("Speed")
+	tp := queue.SpeedToTimePoints(speed)
+	return tp + c.Limit.TimePoints
+}
+
+func (c *CELimit) ShowNotice() {
+	c.Scene.ShowNotice(c.Limit.Name)
+}
+
+//DoStrike one of the LimitStrike hits, each on a random target still standing
+func (c *CELimit) DoStrike() {
+	alive := c.aliveTargets()
+	if len(alive) == 0 {
+		alive = c.Scene.Actors[enemies]
+	}
+	if len(alive) == 0 {
+		return
+	}
+	target := alive[utilz.RandInt(0, len(alive))]
+
+	damage, hitResult := c.Scene.Formula.MeleeAttack(c.Scene, c.owner, target)
+	entity := c.Scene.ActorCharMap[target].Entity
+
+	if hitResult == HitResultMiss {
+		c.Scene.ApplyMiss(target)
+		return
+	} else if hitResult == HitResultDodge {
+		c.Scene.ApplyDodge(target)
+	} else {
+		c.Scene.ApplyElementDamage(target, damage, hitResult == HitResultCritical, c.owner.AttackElement())
+	}
+
+	pos := entity.GetSelectPosition()
+	slashEffect := AnimEntityFxCreate(pos.X, pos.Y, c.AttackEntityDef, c.AttackEntityDef.Frames)
+	c.Scene.AddEffect(slashEffect)
+}
+
+//DoHeal LimitHeal restores every conscious target
+func (c *CELimit) DoHeal() {
+	HpRestore(c.Scene, c.owner, c.aliveTargets(), c.Limit)
+}
+
+func (c *CELimit) aliveTargets() []*combat.Actor {
+	var alive []*combat.Actor
+	for _, v := range c.Targets {
+		if !v.IsKOed() {
+			alive = append(alive, v)
+		}
+	}
+	return alive
+}
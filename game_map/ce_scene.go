@@ -7,14 +7,24 @@ import (
 )
 
 func (c *CombatState) AddTurns(actorList []*combat.Actor) {
-	for _, v := range actorList {
-		hpNow := v.Stats.Get("HpNow")
-		if hpNow > 0 && !c.EventQueue.ActorHasEvent(v) {
-			event := CETurnCreate(c, v)
-			tp := event.TimePoints(c.EventQueue)
-			c.EventQueue.Add(event, tp)
+	for _, v := range combat.TurnsDue(actorList, c.EventQueue.ActorHasEvent, c.IsChoosing) {
+		event := CETurnCreate(c, v)
+		tp := event.TimePoints(c.EventQueue)
+		c.EventQueue.Add(event, tp)
+	}
+}
+
+//IsChoosing actor has its action menu open, its CETurn already finished
+func (c *CombatState) IsChoosing(actor *combat.Actor) bool {
+	if c.SelectedActor == actor {
+		return true
+	}
+	for _, v := range c.InternalStack.States {
+		if choice, ok := v.(*CombatChoiceState); ok && choice.Actor == actor {
+			return true
 		}
 	}
+	return false
 }
 
 func (c *CombatState) GetTarget(owner *combat.Actor) *combat.Actor {
@@ -110,3 +120,11 @@ func (c *CombatState) IsPartyMember(owner *combat.Actor) bool {
 	}
 	return false
 }
+
+//SideOf party or enemies, whichever actor fights for
+func (c *CombatState) SideOf(actor *combat.Actor) []*combat.Actor {
+	if c.IsPartyMember(actor) {
+		return c.Actors[party]
+	}
+	return c.Actors[enemies]
+}
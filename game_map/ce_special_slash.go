@@ -116,7 +116,7 @@ func (c *CESlash) DoAttack() {
 	c.Scene.HideNotice()
 
 	mp := c.mOwner.Stats.Get("MpNow")
-	cost := c.SpecialItem.MpCost
+	cost := c.mOwner.MpCost(c.SpecialItem.MpCost)
 	mp = math.Max(mp-cost, 0)
 	c.mOwner.Stats.Set("MpNow", mp)
 	for _, target := range c.Targets {
@@ -129,14 +129,14 @@ func (c *CESlash) DoAttack() {
 }
 
 func (c *CESlash) CounterTarget(target *combat.Actor) {
-	countered := Formula.IsCountered(c.Scene, c.mOwner, target)
+	countered := c.Scene.Formula.IsCountered(c.Scene, c.mOwner, target)
 	if countered {
 		c.Scene.ApplyCounter(target, c.mOwner)
 	}
 }
 
 func (c *CESlash) AttackTarget(target *combat.Actor) {
-	damage, hitResult := Formula.MeleeAttack(c.Scene, c.mOwner, target)
+	damage, hitResult := c.Scene.Formula.MeleeAttack(c.Scene, c.mOwner, target)
 	entity := c.Scene.ActorCharMap[target].Entity
 
 	if hitResult == HitResultMiss {
@@ -145,7 +145,7 @@ func (c *CESlash) AttackTarget(target *combat.Actor) {
 	} else if hitResult == HitResultDodge {
 		c.Scene.ApplyDodge(target)
 	} else {
-		c.Scene.ApplyDamage(target, damage, hitResult == HitResultCritical)
+		c.Scene.ApplyElementDamage(target, damage, hitResult == HitResultCritical, c.mOwner.AttackElement())
 	}
 
 	pos := entity.GetSelectPosition()
@@ -172,7 +172,7 @@ func (c *CESteal) DoSteal() {
 }
 
 func (c *CESteal) StealFrom(target *combat.Actor) bool {
-	success := Formula.Steal(c.Scene, c.mOwner, target)
+	success := c.Scene.Formula.Steal(c.Scene, c.mOwner, target)
 
 	entity := c.Scene.ActorCharMap[target].Entity
 	pos := entity.GetSelectPosition()
@@ -0,0 +1,184 @@
+package game_map
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//CETech a dual or triple technique, every participant moves out and attacks together
+type CETech struct {
+	owner       *combat.Actor
+	name        string
+	countDown   float64
+	mIsFinished bool
+
+	Tech            world.Tech
+	Members         []*combat.Actor //owner included, in Tech.Members order
+	Targets         []*combat.Actor
+	Scene           *CombatState
+	Character       *Character
+	Storyboard      *Storyboard
+	DefaultTargeter func(state *CombatState) []*combat.Actor
+}
+
+func CETechCreate(scene *CombatState, owner *combat.Actor, members, targets []*combat.Actor, techI interface{}) *CETech {
+	tech := reflect.ValueOf(techI).Interface().(world.Tech)
+	var names []string
+	for _, v := range members {
+		names = append(names, v.Name)
+	}
+	c := &CETech{
+		owner:           owner,
+		name:            fmt.Sprintf("%s are using Tech : %s", strings.Join(names, " & "), tech.Name),
+		Tech:            tech,
+		Members:         members,
+		Targets:         targets,
+		Scene:           scene,
+		Character:       scene.ActorCharMap[owner],
+		DefaultTargeter: CombatSelectorMap[tech.Target.Selector],
+	}
+
+	for _, v := range members {
+		scene.ActorCharMap[v].Controller.Change(csRunanim, csProne, true)
+	}
+
+	storyboardEvents := []interface{}{
+		RunFunction(c.ShowNotice),
+		Wait(0.5),
+		RunFunction(c.partnersRun(csMove, CSMoveParams{Dir: 3})),
+		RunState(c.Character.Controller, csMove, CSMoveParams{Dir: 3}),
+		RunFunction(c.partnersRun(csRunanim, csSpecial, false)),
+		RunState(c.Character.Controller, csRunanim, csSpecial, false),
+		Wait(0.5),
+		RunFunction(c.DoAttack),
+		Wait(0.5),
+		RunFunction(c.partnersRun(csMove, CSMoveParams{Dir: -3})),
+		RunState(c.Character.Controller, csMove, CSMoveParams{Dir: -3}),
+		Wait(0.5),
+		RunFunction(c.partnersRun(csRunanim, csProne, false)),
+		RunState(c.Character.Controller, csRunanim, csProne, false),
+		RunFunction(c.OnFinish),
+	}
+
+	c.Storyboard = StoryboardCreate(scene.InternalStack, scene.win, storyboardEvents, false)
+
+	return c
+}
+
+func (c *CETech) Name() string {
+	return c.name
+}
+
+func (c *CETech) CountDown() float64 {
+	return c.countDown
+}
+
+func (c *CETech) CountDownSet(t float64) {
+	c.countDown = t
+}
+
+func (c *CETech) Owner() *combat.Actor {
+	return c.owner
+}
+
+func (c *CETech) Participants() []*combat.Actor {
+	return c.Members
+}
+
+func (c *CETech) Update() {
+
+}
+
+func (c *CETech) IsFinished() bool {
+	return c.mIsFinished
+}
+
+func (c *CETech) OnFinish() {
+	c.mIsFinished = true
+}
+
+func (c *CETech) Execute(queue *EventQueue) {
+	for _, v := range c.Members {
+		if v.IsKOed() {
+			c.Scene.AddTextEffect(c.owner, "FAILED", 2)
+			c.partnersRun(csStandby, csStandby)()
+			c.Character.Controller.Change(csStandby, csStandby)
+			c.OnFinish()
+			return
+		}
+	}
+	//the partners' turns were given up for this one
+	for _, v := range c.Members {
+		if v != c.owner {
+			c.Scene.HadTurn[v] = true
+			c.Scene.ExpireBuffs(v, v.Stats.TurnPassed())
+		}
+	}
+
+	c.Scene.InternalStack.Push(c.Storyboard)
+	for i := len(c.Targets) - 1; i >= 0; i-- {
+		if c.Targets[i].IsKOed() {
+			c.Targets = removeActorAtIndex(c.Targets, i)
+		}
+	}
+
+	if len(c.Targets) == 0 {
+		c.Targets = c.DefaultTargeter(c.Scene)
+	}
+}
+
+func (c *CETech) TimePoints(queue *EventQueue) float64 {
+	speed := c.owner.Stats.Get("Speed")
+	tp := queue.SpeedToTimePoints(speed)
+	return tp + c.Tech.TimePoints
+}
+
+func (c *CETech) ShowNotice() {
+	c.Scene.ShowNotice(c.Tech.Name)
+}
+
+//partnersRun changes the state of every member but the owner,
+//the storyboard then waits for the owner doing the same
+func (c *CETech) partnersRun(stateId string, params ...interface{}) func() {
+	return func() {
+		for _, v := range c.Members {
+			if v != c.owner {
+				c.Scene.ActorCharMap[v].Controller.Change(stateId, params...)
+			}
+		}
+	}
+}
+
+func (c *CETech) DoAttack() {
+	c.Scene.HideNotice()
+
+	for _, v := range c.Members {
+		mp := v.Stats.Get("MpNow")
+		cost := v.MpCost(c.Tech.MpCost)
+		v.Stats.Set("MpNow", math.Max(mp-cost, 0))
+	}
+	for _, target := range c.Targets {
+		c.AttackTarget(target)
+	}
+}
+
+func (c *CETech) AttackTarget(target *combat.Actor) {
+	entity := c.Scene.ActorCharMap[target].Entity
+	damage := CalcTechDamage(c.Scene, c.Members, target, c.Tech)
+	c.Scene.ApplyElementDamage(target, damage, false, c.Tech.Element)
+
+	if c.Tech.Element == world.SpellFire {
+		AddAnimEffect(c.Scene, entity, Entities["fx_fire"], 0.06)
+	} else if c.Tech.Element == world.SpellBolt {
+		AddAnimEffect(c.Scene, entity, Entities["fx_electric"], 0.12)
+	} else {
+		slash := Entities["slash"]
+		pos := entity.GetSelectPosition()
+		c.Scene.AddEffect(AnimEntityFxCreate(pos.X, pos.Y, slash, slash.Frames))
+	}
+}
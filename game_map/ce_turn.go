@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/world"
 )
 
 //CombatEventTurn
@@ -48,6 +49,9 @@ func (c CETurn) IsFinished() bool {
 }
 
 func (c *CETurn) Execute(queue *EventQueue) {
+	c.Scene.HadTurn[c.owner] = true
+	c.Regenerate()
+	c.Scene.ExpireBuffs(c.owner, c.owner.Stats.TurnPassed())
 
 	// 1. Player
 	if c.Scene.IsPartyMember(c.owner) {
@@ -67,7 +71,25 @@ func (c *CETurn) Execute(queue *EventQueue) {
 
 }
 
+//Regenerate heals owner with world.PassiveRegen
+func (c *CETurn) Regenerate() {
+	hp := c.owner.Regenerate()
+	if hp <= 0 {
+		return
+	}
+	character := c.Scene.ActorCharMap[c.owner]
+	x, y := character.Entity.X, character.Entity.Y
+	c.Scene.AddEffect(JumpingNumbersFXCreate(x, y, hp, "#6dff25"))
+}
+
 func (c CETurn) TimePoints(queue *EventQueue) float64 {
 	speed := c.Owner().Stats.Get("Speed")
-	return queue.SpeedToTimePoints(speed)
+	timePoints := queue.SpeedToTimePoints(speed)
+	if c.Scene.HadTurn[c.owner] {
+		return timePoints
+	}
+	if c.owner.HasPassive(world.PassiveFirstStrike) {
+		return 0
+	}
+	return c.Scene.Start.FirstTurnTimePoints(c.owner.IsPlayer(), timePoints)
 }
@@ -2,8 +2,8 @@ package game_map
 
 import (
 	"fmt"
-	"math"
 	"reflect"
+	"strings"
 
 	"github.com/steelx/go-rpg-cgm/combat"
 	"github.com/steelx/go-rpg-cgm/world"
@@ -14,23 +14,23 @@ var CombatActions = map[world.Action]func(state *CombatState, owner *combat.Acto
 	world.MpRestore:    MpRestore,
 	world.Revive:       Revive,
 	world.ElementSpell: elementSpell,
+	world.ItemDamage:   itemDamage,
+	world.CureStatus:   cureStatus,
+	world.StatBuff:     statBuff,
+	world.Escape:       escape,
+	world.Scan:         scan,
 }
 
 func HpRestore(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
-	def := reflect.ValueOf(defI).Interface().(world.Item)
-	restoreAmount := def.Use.Restore
+	restoreAmount := CalcHealAmount(state, owner, defI)
 	animEffect := Entities["fx_restore_hp"]
 	restoreColor := "#00ff45"
 
 	for _, v := range targets {
-		stats, _, entity := StatsCharEntity(state, v)
-		maxHP := stats.Get("HpMax")
-		nowHP := stats.Get("HpNow")
+		_, _, entity := StatsCharEntity(state, v)
 
-		if nowHP > 0 {
+		if v.RestoreHP(restoreAmount) > 0 {
 			AddTextNumberEffect(state, entity, restoreAmount, restoreColor)
-			nowHP = math.Min(maxHP, nowHP+restoreAmount)
-			stats.Set("HpNow", nowHP)
 		}
 
 		AddAnimEffect(state, entity, animEffect, 0.1)
@@ -38,21 +38,15 @@ func HpRestore(state *CombatState, owner *combat.Actor, targets []*combat.Actor,
 }
 
 func MpRestore(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
-	def := reflect.ValueOf(defI).Interface().(world.Item)
-	restoreAmount := def.Use.Restore
+	restoreAmount := combat.RestoreAmount(defI)
 	animEffect := Entities["fx_restore_mp"]
 	restoreColor := "#00ffff"
 
 	for _, v := range targets {
-		stats, _, entity := StatsCharEntity(state, v)
-		maxMP := stats.Get("MpMax")
-		nowMP := stats.Get("MpNow")
-		nowHP := stats.Get("HpNow")
+		_, _, entity := StatsCharEntity(state, v)
 
-		if nowHP > 0 {
+		if v.RestoreMP(restoreAmount) > 0 {
 			AddTextNumberEffect(state, entity, restoreAmount, restoreColor)
-			nowMP = math.Min(maxMP, nowMP+restoreAmount)
-			stats.Set("MpNow", nowHP)
 		}
 
 		AddAnimEffect(state, entity, animEffect, 0.1)
@@ -60,24 +54,17 @@ func MpRestore(state *CombatState, owner *combat.Actor, targets []*combat.Actor,
 }
 
 func Revive(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
-	def := reflect.ValueOf(defI).Interface().(world.Item)
-	restoreAmount := def.Use.Restore
+	restoreAmount := combat.RestoreAmount(defI)
 	animEffect := Entities["fx_revive"]
 	restoreColor := "#00ff00"
 
 	for _, v := range targets {
-		stats, character, entity := StatsCharEntity(state, v)
-		maxHP := stats.Get("HpMax")
-		nowHP := stats.Get("HpNow")
-
-		if nowHP <= 0 {
-			nowHP = math.Min(maxHP, nowHP+restoreAmount)
+		_, character, entity := StatsCharEntity(state, v)
 
+		if v.Revive(restoreAmount) > 0 {
 			// the character will get a CETurn event automatically
 			// assigned next update
 			character.Controller.Change(csStandby, csStandby)
-
-			stats.Set("HpNow", nowHP)
 			AddTextNumberEffect(state, entity, restoreAmount, restoreColor)
 		}
 
@@ -85,6 +72,67 @@ func Revive(state *CombatState, owner *combat.Actor, targets []*combat.Actor, de
 	}
 }
 
+func itemDamage(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
+	def := reflect.ValueOf(defI).Interface().(world.Item)
+	animEffect := Entities["fx_bomb"]
+
+	for _, v := range targets {
+		_, _, entity := StatsCharEntity(state, v)
+		AddAnimEffect(state, entity, animEffect, 0.08)
+		state.ApplyElementDamage(v, CalcItemDamage(state, v, def), false, def.Use.Element)
+	}
+}
+
+func cureStatus(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
+	animEffect := Entities["fx_cure"]
+
+	for _, v := range targets {
+		_, _, entity := StatsCharEntity(state, v)
+		if v.CureStatus(combat.CureList(defI)) {
+			state.AddTextEffect(v, "CURED", 2)
+		}
+		AddAnimEffect(state, entity, animEffect, 0.1)
+	}
+}
+
+func statBuff(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
+	def := reflect.ValueOf(defI).Interface().(world.Item)
+	animEffect := Entities["fx_buff"]
+
+	for _, v := range targets {
+		_, _, entity := StatsCharEntity(state, v)
+		if !state.AddBuff(v, combat.ItemBuffSource(def), def.Use.Buff) {
+			continue
+		}
+		state.AddTextEffect(v, def.Use.Buff.Name, 2)
+		AddAnimEffect(state, entity, animEffect, 0.1)
+	}
+}
+
+//escape queues a flee which can not fail, right after the item is used
+func escape(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
+	_, _, entity := StatsCharEntity(state, owner)
+	AddAnimEffect(state, entity, Entities["fx_escape"], 0.1)
+
+	event := CEEscapeCreate(state, owner, CSMoveParams{Dir: 8, Distance: 180, Time: 0.6})
+	state.EventQueue.Add(event, -1)
+}
+
+//scan shows every target's stats in one textbox, the item's Storyboard waits
+//on the InternalStack until the player closes it
+func scan(state *CombatState, owner *combat.Actor, targets []*combat.Actor, defI interface{}) {
+	animEffect := Entities["fx_scan"]
+
+	var results []string
+	for _, v := range targets {
+		_, _, entity := StatsCharEntity(state, v)
+		AddAnimEffect(state, entity, animEffect, 0.1)
+		results = append(results, v.ScanText())
+	}
+	x, y := state.Layout.MidX("notice"), state.Layout.MidY("notice")
+	state.InternalStack.PushFitted(x, y, strings.Join(results, "\n"))
+}
+
 func AddAnimEffect(state *CombatState, entity *Entity, fxEntityDef EntityDefinition, spf float64) {
 	pos := entity.GetSelectPosition()
 	x := pos.X
@@ -114,10 +162,17 @@ func elementSpell(state *CombatState, owner *combat.Actor, targets []*combat.Act
 	def := reflect.ValueOf(defI).Interface().(world.SpecialItem)
 
 	for _, v := range targets {
+		if v.Affinity(def.Element) == combat.AffinityReflect {
+			state.AddTextEffect(v, combat.AffinityReflect.CombatText(), 2)
+			// bounced spells hit the caster's side and aren't reflected again
+			if v = state.ReflectTarget(owner); v == nil {
+				continue
+			}
+		}
 		_, _, entity := StatsCharEntity(state, v)
 		damage, hitResult := MagicAttack(state, owner, v, def)
 		if hitResult == HitResultHit {
-			state.ApplyDamage(v, damage, true)
+			state.ApplyElementDamage(v, damage, true, def.Element)
 		}
 
 		if def.Element == world.SpellFire {
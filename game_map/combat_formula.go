@@ -30,8 +30,11 @@ type FormulaT struct {
 	MostDrainedParty func(state *CombatState) []*combat.Actor
 	DeadParty        func(state *CombatState) []*combat.Actor
 	Steal            func(state *CombatState, attacker, target *combat.Actor) bool
+	Magic            combat.MagicFormula //spell damage, healing power and status hit chance
+	Rules            combat.RuleProfile  //constants read by the functions above
 }
 
+//Formula default profile, a CombatState uses its own, see RuleProfiles
 var Formula = FormulaT{
 	MeleeAttack: meleeAttack,
 	BaseAttack:  baseAttack,
@@ -41,6 +44,8 @@ var Formula = FormulaT{
 	IsCountered: isCountered,
 	CanFlee:     canFlee,
 	Steal:       Steal,
+	Magic:       combat.RevisedMagic,
+	Rules:       combat.DefaultRuleProfile,
 }
 
 func meleeAttack(state *CombatState, attacker, target *combat.Actor) (dmg float64, hit HitResult) {
@@ -59,26 +64,31 @@ func meleeAttack(state *CombatState, attacker, target *combat.Actor) (dmg float6
 	}
 
 	damage = calcDamage(state, attacker, target)
-
-	if hitResult == HitResultHit {
-		return math.Floor(damage), HitResultHit
+	if hitResult == HitResultCritical {
+		damage = damage + baseAttack(state, attacker, target)
 	}
 
-	// Critical
-	damage = damage + baseAttack(state, attacker, target)
-	return math.Floor(damage), HitResultCritical
+	rows := combat.RowMultiplier(attacker, state.SideOf(attacker), target, state.SideOf(target))
+	// the weapon's element, negative damage means the target absorbs it
+	affinity := target.Affinity(attacker.AttackElement()).Multiplier()
+	// the profile scales what enemies deal, not what their victims absorb
+	if !state.IsPartyMember(attacker) && affinity > 0 {
+		damage *= state.Formula.Rules.EnemyDamage
+	}
+	return math.Floor(damage * rows * affinity), hitResult
 }
 
 func isHit(state *CombatState, attacker, target *combat.Actor) HitResult {
 	stats := attacker.Stats
-	speed := stats.Get("Speed")
-	intelligence := stats.Get("Intelligence")
-
-	cth := 0.8 //Chance to Hit
-	ctc := 0.1 //Chance to Crit
-
-	bonus := ((speed + intelligence) / 2) / 255 //divide by max value
-	cth = cth + (bonus / 2)
+	rules := state.Formula.Rules
+	cth := stats.Value(world.StatHitChance)  //Chance to Hit
+	ctc := stats.Value(world.StatCritChance) //Chance to Crit
+	if state.IsPartyMember(attacker) {
+		cth += rules.HitBonus
+		ctc += rules.CritBonus
+	} else {
+		cth += rules.EnemyHitBonus
+	}
 
 	rand := utilz.RandFloat(0, 1)
 	isHit := rand <= cth
@@ -100,7 +110,7 @@ func isDodged(state *CombatState, attacker, target *combat.Actor) bool {
 	speed := stats.Get("Speed")
 	enemySpeed := enemyStats.Get("Speed")
 
-	ctd := 0.03 //Chance to Dodge
+	ctd := enemyStats.Value(world.StatEvasion) //Chance to Dodge
 	speedDiff := speed - enemySpeed
 	// clamp speed diff to plus or minus 10%
 	speedDiff = utilz.Clamp(speedDiff, -10, 10) * 0.01
@@ -113,6 +123,7 @@ func isDodged(state *CombatState, attacker, target *combat.Actor) bool {
 func isCountered(state *CombatState, attacker, target *combat.Actor) bool {
 	// if not assigned 0 is returned, which will mean no chance of countering
 	counter := target.Stats.Get("Counter")
+	counter += target.PassiveValue(world.PassiveCounterBoost)
 
 	// I want random to be between 0 and under 1
 	// This means 1 always counters and 0 it never happens
@@ -137,23 +148,7 @@ func calcDamage(state *CombatState, attacker, target *combat.Actor) (dmg float64
 }
 
 func canFlee(state *CombatState, target *combat.Actor) bool {
-	fc := 0.35 // flee chance
-	stats := target.Stats
-	speed := stats.Get("Speed")
-
-	// Get the average speed of the enemies
-	var enemyCount, totalSpeed float64
-	for _, v := range state.Actors[enemies] {
-		speed := v.Stats.Get("Speed")
-		totalSpeed += speed
-		enemyCount += 1
-	}
-	avgSpeed := totalSpeed / enemyCount
-	if speed > avgSpeed {
-		fc = fc + 0.15
-	} else {
-		fc = fc - 0.15
-	}
+	fc := combat.FleeChance(state.Formula.Rules, target, state.Actors[enemies]) // flee chance
 	return utilz.RandFloat(0, 1) <= fc
 }
 
@@ -168,26 +163,44 @@ func IsHitMagic(state *CombatState, attacker, target *combat.Actor, spell world.
 }
 
 func CalcSpellDamage(state *CombatState, attacker, target *combat.Actor, spell world.SpecialItem) (damage float64) {
-	// Find the basic damage
+	// Find the basic damage, then increase power of spell by caster
 	base := utilz.RandFloat(spell.BaseDamage[0], spell.BaseDamage[1])
-	damage = base * 4
-	// Increase power of spell by caster
-	level := attacker.Level
-	stats := attacker.Stats
-	bonus := float64(level) * stats.Get("Intelligence") * (base / 32)
+	damage = state.Formula.Magic.SpellDamage(attacker, base)
+
+	return ApplyResistance(state, target, spell.Element, damage)
+}
 
-	damage += bonus
+//ApplyResistance elemental weakness / strength, magic defense and Affinity of target,
+//negative damage means the target absorbs it
+func ApplyResistance(state *CombatState, target *combat.Actor, element string, damage float64) float64 {
+	return state.Formula.Magic.Resisted(target, element, damage)
+}
 
-	// Apply elemental weakness / strength modifications
-	if spell.Element != "" {
-		modifier := target.Stats.Get(spell.Element)
-		damage += damage * modifier
+//CalcHealAmount HP restored by a healing spell, items always restore their amount
+func CalcHealAmount(state *CombatState, caster *combat.Actor, defI interface{}) float64 {
+	amount := combat.RestoreAmount(defI)
+	if _, ok := defI.(world.SpecialItem); ok {
+		return state.Formula.Magic.HealPower(caster, amount)
 	}
-	// Handle resistance [0..255]
-	resist := math.Min(255, target.Stats.Get("Resist"))
-	resist01 := 1 - (resist / 255)
-	damage = damage * resist01
-	return damage
+	return amount
+}
+
+//IsStatusHit a status of chance e.g. 0.5 sticks to target, see MagicFormula.StatusChance
+func IsStatusHit(state *CombatState, attacker, target *combat.Actor, chance float64) bool {
+	return utilz.RandFloat(0, 1) < state.Formula.Magic.StatusChance(attacker, target, chance)
+}
+
+//CalcTechDamage combined attack of every member, reduced by Defense and then
+//by Resist like a spell of the tech's element
+func CalcTechDamage(state *CombatState, members []*combat.Actor, target *combat.Actor, tech world.Tech) float64 {
+	attack := combat.TechAttack(tech, members)
+	damage := math.Max(0, utilz.RandFloat(attack, attack*1.5)-target.Stats.Get("Defense"))
+	return math.Floor(ApplyResistance(state, target, tech.Element, damage))
+}
+
+//CalcItemDamage e.g. bombs, item power doesn't depend on who throws it
+func CalcItemDamage(state *CombatState, target *combat.Actor, item world.Item) float64 {
+	return combat.ItemDamage(state.Formula.Magic, target, item, utilz.RandFloat(0, 1))
 }
 
 func MagicAttack(state *CombatState, attacker, target *combat.Actor, spell world.SpecialItem) (float64, HitResult) {
@@ -203,12 +216,14 @@ func MagicAttack(state *CombatState, attacker, target *combat.Actor, spell world
 }
 
 func Steal(state *CombatState, attacker, target *combat.Actor) bool {
-	cts := 0.50 // 50% chance to steal
+	rules := state.Formula.Rules
+	cts := rules.StealChance // 50% chance to steal by default
 
 	if attacker.Level > target.Level {
 		cts = float64(50+attacker.Level-target.Level) / 128
-		cts = utilz.Clamp(cts, 0.05, 0.95)
 	}
+	cts += attacker.PassiveValue(world.PassiveStealBonus)
+	cts = utilz.Clamp(cts, rules.StealMin, rules.StealMax)
 
 	randN := utilz.RandFloat(0, 1) //wondering if should be 0 to 1 or higher
 	return randN <= cts
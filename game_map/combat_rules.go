@@ -0,0 +1,58 @@
+package game_map
+
+import (
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/resources"
+	"github.com/steelx/go-rpg-cgm/utilz"
+)
+
+//RuleProfiles a FormulaT for every profile in combat_rules.csv e.g. "hard",
+//chosen by CombatDef.Rules or else the Difficulty setting
+var RuleProfiles = make(map[string]FormulaT)
+
+//RuleProfileOrder profile names in file order, cycled by the Difficulty setting
+var RuleProfileOrder []string
+
+func init() {
+	f, err := resources.FS.Open("combat_rules.csv")
+	utilz.PanicIfErr(err)
+	defer f.Close()
+
+	profiles, err := combat.ParseRuleProfiles(f)
+	utilz.PanicIfErr(err)
+	for _, rules := range profiles {
+		RuleProfiles[rules.Name] = FormulaWithRules(rules)
+		RuleProfileOrder = append(RuleProfileOrder, rules.Name)
+	}
+}
+
+//FormulaWithRules the default Formula functions reading the constants of rules
+func FormulaWithRules(rules combat.RuleProfile) FormulaT {
+	f := Formula
+	f.Rules = rules
+	f.Magic = combat.MagicFormulas[rules.Magic]
+	return f
+}
+
+//RuleProfileFor the first of names found in RuleProfiles, Formula if none is
+func RuleProfileFor(names ...string) FormulaT {
+	for _, name := range names {
+		if f, ok := RuleProfiles[name]; ok {
+			return f
+		}
+	}
+	return Formula
+}
+
+//NextRuleProfile name after current in RuleProfileOrder, cycling back to the first
+func NextRuleProfile(current string) string {
+	for i, name := range RuleProfileOrder {
+		if name == current {
+			return RuleProfileOrder[(i+1)%len(RuleProfileOrder)]
+		}
+	}
+	if len(RuleProfileOrder) == 0 {
+		return current
+	}
+	return RuleProfileOrder[0]
+}
@@ -31,6 +31,25 @@ var CombatSelectorMap = map[string]func(state *CombatState) []*combat.Actor{
 	world.DeadParty: func(state *CombatState) []*combat.Actor {
 		return DeadActors(state.Actors[party])
 	},
+	world.AfflictedParty: func(state *CombatState) []*combat.Actor {
+		return AfflictedActors(state.Actors[party])
+	},
+	world.SideParty: func(state *CombatState) []*combat.Actor {
+		return state.Actors[party]
+	},
+}
+
+//FieldSelectorMap picks the default party target outside of combat,
+//e.g. in-game menu cursor starts on the most hurt member for a Heal Potion
+var FieldSelectorMap = map[string]func(party []*combat.Actor) []*combat.Actor{
+	world.MostHurtParty: func(party []*combat.Actor) []*combat.Actor {
+		return WeakestActor(party, true)
+	},
+	world.MostDrainedParty: func(party []*combat.Actor) []*combat.Actor {
+		return MostDrainedActor(party, true)
+	},
+	world.DeadParty:      DeadActors,
+	world.AfflictedParty: AfflictedActors,
 }
 
 var CombatSelector = CombatSelectorFunc{
@@ -140,3 +159,14 @@ func DeadActors(actors []*combat.Actor) []*combat.Actor {
 
 	return []*combat.Actor{actors[0]}
 }
+
+//AfflictedActors first actor suffering from any status ailment
+func AfflictedActors(actors []*combat.Actor) []*combat.Actor {
+	for _, v := range actors {
+		if v.HasAnyStatus() {
+			return []*combat.Actor{v}
+		}
+	}
+
+	return []*combat.Actor{actors[0]}
+}
@@ -194,4 +194,39 @@ var Entities = map[string]EntityDefinition{
 		StartFrame: 13,
 		Frames:     []int{12, 13, 14, 15},
 	},
+	"fx_bomb": {
+		Texture:    fxFirePng,
+		Width:      32,
+		Height:     48,
+		StartFrame: 0,
+		Frames:     []int{0, 1, 2, 1, 0},
+	},
+	"fx_cure": {
+		Texture:    fxRestoreHpPng,
+		Width:      16,
+		Height:     16,
+		StartFrame: 4,
+		Frames:     []int{4, 3, 2, 1, 0},
+	},
+	"fx_buff": {
+		Texture:    fxRevivePng,
+		Width:      16,
+		Height:     16,
+		StartFrame: 0,
+		Frames:     []int{0, 2, 4, 6, 4, 2, 0},
+	},
+	"fx_escape": {
+		Texture:    fxUseItemPng,
+		Width:      16,
+		Height:     16,
+		StartFrame: 0,
+		Frames:     []int{0, 1, 2, 3, 2, 1, 0},
+	},
+	"fx_scan": {
+		Texture:    fxElectricPng,
+		Width:      32,
+		Height:     16,
+		StartFrame: 0,
+		Frames:     []int{2, 1, 0},
+	},
 }
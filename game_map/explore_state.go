@@ -2,11 +2,14 @@ package game_map
 
 import (
 	"log"
+	"reflect"
 	"sort"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
+	"github.com/steelx/go-rpg-cgm/combat"
 	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/world"
 	"github.com/steelx/tilepix"
 )
 
@@ -143,6 +146,15 @@ func (es ExploreState) HandleInput(win *pixelgl.Window) {
 		trigger := es.Map.GetTrigger(tileX, tileY)
 		if trigger.OnUse != nil {
 			trigger.OnUse(es.Map, es.Hero.Entity, tileX, tileY)
+		} else if trigger.OnUseItem != nil {
+			es.ChooseKeyItem(trigger, tileX, tileY)
+		}
+	}
+	if win.JustPressed(pixelgl.KeyE) {
+		tileX, tileY := es.Hero.GetFacedTileCoords()
+		trigger := es.Map.GetTrigger(tileX, tileY)
+		if trigger.OnUseItem != nil {
+			es.ChooseKeyItem(trigger, tileX, tileY)
 		}
 	}
 	if win.JustPressed(pixelgl.KeyLeftAlt) {
@@ -154,3 +166,31 @@ func (es ExploreState) HandleInput(win *pixelgl.Window) {
 func (es *ExploreState) AddNPC(NPC *Character) {
 	es.Map.AddNPC(NPC)
 }
+
+//ChooseKeyItem lets the player pick one of World.KeyItems and use it on trigger
+func (es ExploreState) ChooseKeyItem(trigger Trigger, tileX, tileY float64) {
+	gWorld := reflect.ValueOf(es.Stack.Globals["world"]).Interface().(*combat.WorldExtended)
+	x, y := es.Map.GetTileIndex(tileX, tileY)
+	if len(gWorld.KeyItems) == 0 {
+		es.Stack.PushFitted(x, y, "You have no key items.")
+		return
+	}
+
+	keyItems := append([]world.ItemIndex{}, gWorld.KeyItems...)
+	var choices []string
+	for _, v := range keyItems {
+		choices = append(choices, world.ItemsDB[v.Id].Name)
+	}
+
+	onSelection := func(index int, c interface{}) {
+		es.Stack.Pop() //remove selection menu
+		itemId := keyItems[index].Id
+		if !trigger.OnUseItem(es.Map, es.Hero.Entity, tileX, tileY, itemId) {
+			es.Stack.PushFitted(x, y, "That doesn't fit.")
+			return
+		}
+		gWorld.ConsumeKeyItem(itemId)
+	}
+	height := 70 + float64(len(choices))*24
+	es.Stack.PushSelectionMenu(x, y, 400, height, "Use which key item?", choices, onSelection, false)
+}
@@ -34,6 +34,7 @@ type GameMap struct {
 	renderLayer           int
 
 	Actions        map[string]func(gMap *GameMap, entity *Entity, x, y float64)
+	ItemActions    map[string]func(gMap *GameMap, entity *Entity, x, y float64, itemId int) bool
 	TriggerTypes   map[string]Trigger
 	Triggers       map[[2]float64]Trigger
 	OnWakeTriggers map[string]Trigger
@@ -76,7 +77,12 @@ func MapCreate(mapInfo MapInfo) *GameMap {
 
 func (m *GameMap) createTriggersFromMapInfo() {
 	m.Actions = make(map[string]func(gMap *GameMap, entity *Entity, x, y float64))
+	m.ItemActions = make(map[string]func(gMap *GameMap, entity *Entity, x, y float64, itemId int) bool)
 	for name, def := range m.MapInfo.Actions {
+		if def.Id == "RunItemScript" {
+			m.ItemActions[name] = RunItemScript(def.ItemScript)
+			continue
+		}
 		//def.Id = RunScript
 		action := RunScript(def.Script)
 		m.Actions[name] = action
@@ -86,9 +92,10 @@ func (m *GameMap) createTriggersFromMapInfo() {
 	m.TriggerTypes = make(map[string]Trigger)
 	for k, v := range m.MapInfo.TriggerTypes {
 		m.TriggerTypes[k] = Trigger{
-			OnEnter: m.Actions[v.OnEnter],
-			OnExit:  m.Actions[v.OnExit],
-			OnUse:   m.Actions[v.OnUse],
+			OnEnter:   m.Actions[v.OnEnter],
+			OnExit:    m.Actions[v.OnExit],
+			OnUse:     m.Actions[v.OnUse],
+			OnUseItem: m.ItemActions[v.OnUseItem],
 		}
 	}
 
@@ -6,3 +6,10 @@ func RunScript(script func(gMap *GameMap, entity *Entity, x, y float64)) func(gM
 		script(gMap, entity, x, y)
 	}
 }
+
+func RunItemScript(script func(gMap *GameMap, entity *Entity, x, y float64, itemId int) bool) func(gMap *GameMap, entity *Entity, x, y float64, itemId int) bool {
+
+	return func(gMap *GameMap, entity *Entity, x, y float64, itemId int) bool {
+		return script(gMap, entity, x, y, itemId)
+	}
+}
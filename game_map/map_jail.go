@@ -124,38 +124,27 @@ func mapJail(gStack *gui.StateStack) MapInfo {
 	}
 
 	playUnlock := PlayBGSound("../sound/unlock.mp3")
-	grillOnUse := func(gameMap *GameMap, entity *Entity, tileX, tileY float64) {
-		if !worldI.HasKey(boneItemId) {
-			return
+	//Space or E on the grill lets player choose a key item, see ExploreState.ChooseKeyItem
+	grillOnUseItem := func(gameMap *GameMap, entity *Entity, tileX, tileY float64, itemId int) bool {
+		if itemId != boneItemId {
+			return false
 		}
 
 		x, y := gameMap.GetTileIndex(tileX, tileY)
-		grillOpen := func(gMap *GameMap) {
-			gStack.Pop()
-			gStack.PushFitted(x, y, "The drain opened, and leads a way inside the sewers")
-			playUnlock()
-
-			gMap.RemoveTrigger(32, 15)
-			gMap.RemoveTrigger(33, 15)
-			gMap.WriteTile(32, 15, false)
-			gMap.WriteTile(33, 15, false)
-			gMap.SetHiddenTileVisible(32, 15)
-			gMap.SetHiddenTileVisible(33, 15)
+		gStack.PushFitted(x, y, "You pry the grill open with the bone. The drain leads a way inside the sewers")
+		playUnlock()
 
-			//now we add new trigger onEnter
-			gMap.AddTrigger("grill_when_open", 32, 15)
-			gMap.AddTrigger("grill_when_open", 33, 15)
-		}
+		gameMap.RemoveTrigger(32, 15)
+		gameMap.RemoveTrigger(33, 15)
+		gameMap.WriteTile(32, 15, false)
+		gameMap.WriteTile(33, 15, false)
+		gameMap.SetHiddenTileVisible(32, 15)
+		gameMap.SetHiddenTileVisible(33, 15)
 
-		choices := []string{"Pry open the grill", "Leave it alone"}
-		onSelection := func(index int, c interface{}) {
-			if index == 0 {
-				grillOpen(gameMap)
-			}
-		}
-		gStack.PushSelectionMenu(
-			x, y, 400, 110,
-			"Do you want to dig teh grill?", choices, onSelection, false)
+		//now we add new trigger onEnter
+		gameMap.AddTrigger("grill_when_open", 32, 15)
+		gameMap.AddTrigger("grill_when_open", 33, 15)
+		return true
 	}
 
 	//jail break
@@ -246,9 +235,9 @@ func mapJail(gStack *gui.StateStack) MapInfo {
 				Id:     "RunScript",
 				Script: talkGregor,
 			},
-			"grill_on_use": {
-				Id:     "RunScript",
-				Script: grillOnUse,
+			"grill_on_use_item": {
+				Id:         "RunItemScript",
+				ItemScript: grillOnUseItem,
 			},
 			"grill_on_enter": {
 				Id:     "RunScript",
@@ -269,7 +258,7 @@ func mapJail(gStack *gui.StateStack) MapInfo {
 				OnExit: "move_gregor",
 			},
 			"grill_when_closed": {
-				OnUse: "grill_on_use",
+				OnUseItem: "grill_on_use_item",
 			},
 			"grill_when_open": {
 				OnEnter: "grill_on_enter",
@@ -18,14 +18,16 @@ func init() {
 }
 
 type MapAction struct {
-	Id     string
-	Script func(gameMap *GameMap, entity *Entity, x, y float64)
+	Id         string
+	Script     func(gameMap *GameMap, entity *Entity, x, y float64)
+	ItemScript func(gameMap *GameMap, entity *Entity, x, y float64, itemId int) bool //Id = "RunItemScript"
 }
 
 type TriggerType struct {
-	OnUse   string
-	OnEnter string
-	OnExit  string
+	OnUse     string
+	OnEnter   string
+	OnExit    string
+	OnUseItem string
 }
 
 type TriggerParam struct {
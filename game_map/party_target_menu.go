@@ -0,0 +1,91 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/world"
+)
+
+//PartyTargetMenu lets the player choose party members outside of combat,
+//e.g. who drinks the Heal Potion. Cursor starts on the member
+//picked by ItemTarget.Selector, CombatTargetTypeALL targets everyone
+type PartyTargetMenu struct {
+	Party      []*combat.Actor
+	TargetType world.CombatTargetType
+	Menu       *gui.SelectionMenu
+	OnSelect   func(targets []*combat.Actor)
+}
+
+func PartyTargetMenuCreate(party []*combat.Actor, target world.ItemTarget, onSelect func(targets []*combat.Actor)) *PartyTargetMenu {
+	p := &PartyTargetMenu{
+		Party:      party,
+		TargetType: target.Type,
+		OnSelect:   onSelect,
+	}
+
+	var summaries []combat.ActorSummary
+	for _, actor := range party {
+		summaries = append(summaries, combat.ActorSummaryCreate(*actor, false))
+	}
+
+	menu := gui.SelectionMenuCreate(100, 0, 380,
+		summaries,
+		false,
+		pixel.V(0, 0),
+		p.onMemberChosen,
+		p.renderSummary,
+	)
+	p.Menu = &menu
+
+	if selectorF, ok := FieldSelectorMap[target.Selector]; ok && len(party) > 0 {
+		chosen := selectorF(party)[0]
+		for i := 0; i < len(party) && party[i] != chosen; i++ {
+			p.Menu.MoveDown()
+		}
+	}
+
+	return p
+}
+
+func (p *PartyTargetMenu) onMemberChosen(index int, _ interface{}) {
+	if p.TargetType == world.CombatTargetTypeONE {
+		p.OnSelect([]*combat.Actor{p.Party[index]})
+		return
+	}
+	p.OnSelect(p.Party)
+}
+
+func (p PartyTargetMenu) renderSummary(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, actorSummary ActorSummary
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	actorSummary := reflect.ValueOf(a[3]).Interface().(combat.ActorSummary)
+
+	actorSummary.SetPosition(x, y+35)
+	actorSummary.Render(renderer)
+}
+
+func (p *PartyTargetMenu) HandleInput(win *pixelgl.Window) {
+	if p.Menu.IsDataSourceEmpty() {
+		return
+	}
+	p.Menu.HandleInput(win)
+}
+
+func (p PartyTargetMenu) Render(renderer *pixelgl.Window, x, y float64) {
+	if p.TargetType != world.CombatTargetTypeONE {
+		textBase := text.New(pixel.V(x, y+20), gui.BasicAtlasAscii)
+		fmt.Fprintln(textBase, "Target: whole party")
+		textBase.Draw(renderer, pixel.IM)
+	}
+
+	p.Menu.SetPosition(x, y)
+	p.Menu.Render(renderer)
+}
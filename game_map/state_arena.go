@@ -20,12 +20,12 @@ var rounds = []*ArenaRound{
 		combat.GoblinDef,
 		combat.GoblinDef,
 	}},
-	{Name: "Round 3", Locked: true, Enemies: []combat.ActorDef{
+	{Name: "Round 3", Locked: true, Formation: combat.FormationSurrounded, Enemies: []combat.ActorDef{
 		combat.GoblinDef,
 		combat.GoblinDef,
 		combat.GoblinDef,
 	}},
-	{Name: "Round 4", Locked: true, Enemies: []combat.ActorDef{
+	{Name: "Round 4", Locked: true, Formation: combat.FormationPincer, Enemies: []combat.ActorDef{
 		combat.OgreDef,
 		combat.OgreDef,
 	}},
@@ -35,9 +35,10 @@ var rounds = []*ArenaRound{
 }
 
 type ArenaRound struct {
-	Name    string
-	Locked  bool
-	Enemies []combat.ActorDef
+	Name      string
+	Locked    bool
+	Enemies   []combat.ActorDef
+	Formation combat.CombatFormation
 }
 
 type ArenaState struct {
@@ -129,10 +130,12 @@ func (s *ArenaState) OnRoundSelected(index int, itemI interface{}) {
 	combatDef := CombatDef{
 		Background: "../resources/arena_background.png",
 		Actors: Actors{
-			Party:   s.World.Party.ToArray(),
+			Party:   s.World.Party.Active(),
 			Enemies: enemyList,
 		},
-		CanFlee: false,
+		CanFlee:   false,
+		Formation: item.Formation,
+		Trigger:   combat.TriggerParty,
 		OnWin: func() {
 			s.WinRound(index, item)
 		},
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
@@ -45,10 +46,41 @@ func CombatChoiceStateCreate(combatState *CombatState, owner *combat.Actor) *Com
 		Marker:      pixel.NewSprite(gui.ContinueCaretPng, gui.ContinueCaretPng.Bounds()),
 	}
 	c.MarkerPosition = c.Character.Entity.GetSelectPosition()
-	c.CreateActionDialog(owner.Actions)
+
+	choices := append([]string{}, owner.Actions...)
+	if owner.LimitReady() {
+		choices = append(choices, combat.ActionLimit)
+	}
+	if len(c.AvailableTechs()) > 0 {
+		choices = append(choices, combat.ActionTech)
+	}
+	choices = append(choices, combat.ActionRow)
+	if len(c.SwapCandidates()) > 0 {
+		choices = append(choices, combat.ActionSwap)
+	}
+	c.CreateActionDialog(choices)
 	return c
 }
 
+//SwapCandidates reserve members able to take the Actor's place
+func (c CombatChoiceState) SwapCandidates() []*combat.Actor {
+	var list []*combat.Actor
+	for _, actor := range c.World.Party.Reserve() {
+		if !actor.IsKOed() {
+			list = append(list, actor)
+		}
+	}
+	return list
+}
+
+//AvailableTechs the Actor can start now, partners need their turn ready in the EventQueue
+func (c CombatChoiceState) AvailableTechs() []world.Tech {
+	queue := c.CombatState.EventQueue
+	return combat.AvailableTechs(c.Actor, c.CombatState.Actors[party], func(a *combat.Actor) bool {
+		return a == c.Actor || queue.TurnReady(a)
+	})
+}
+
 func (c *CombatChoiceState) Enter() {
 	c.CombatState.SelectedActor = c.Actor
 }
@@ -107,6 +139,27 @@ func (c *CombatChoiceState) OnSelect(index int, str interface{}) {
 		return
 	}
 
+	if actionItem == combat.ActionRow {
+		c.Stack.Pop() // choice state
+		c.CombatState.ChangeRow(c.Actor)
+		return
+	}
+
+	if actionItem == combat.ActionSwap {
+		c.OnSwapAction()
+		return
+	}
+
+	if actionItem == combat.ActionLimit {
+		c.OnLimitAction()
+		return
+	}
+
+	if actionItem == combat.ActionTech {
+		c.OnTechAction()
+		return
+	}
+
 	if actionItem == combat.ActionMagic {
 		c.OnMagicAction()
 		return
@@ -242,6 +295,161 @@ func (c *CombatChoiceState) OnItemAction() {
 	c.Stack.Push(state)
 }
 
+//OnSwapAction a reserve member takes the Actor's place, using up its turn
+func (c *CombatChoiceState) OnSwapAction() {
+	itemsSelectionWidth := 150.0
+	x := c.Selection.X - (itemsSelectionWidth / 2)
+	y := c.Selection.Y - (itemsSelectionWidth / 2)
+	c.Selection.HideCursor()
+
+	OnExit := func() {
+		c.Selection.ShowCursor()
+	}
+
+	OnRenderItem := func(a ...interface{}) {
+		//renderer pixel.Target, x, y float64, actor *combat.Actor
+		renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+		x := reflect.ValueOf(a[1]).Interface().(float64)
+		y := reflect.ValueOf(a[2]).Interface().(float64)
+		actor := reflect.ValueOf(a[3]).Interface().(*combat.Actor)
+
+		textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+		fmt.Fprintf(textBase, "%s %v/%v", actor.Name, actor.Stats.Get("HpNow"), actor.Stats.Get("HpMax"))
+		textBase.Draw(renderer, pixel.IM)
+	}
+
+	OnSelection := func(selection *BrowseListState, index int, actorI interface{}) {
+		actor := reflect.ValueOf(actorI).Interface().(*combat.Actor)
+		c.Stack.Pop() //browse state
+		c.Stack.Pop() //choice state
+		c.CombatState.SwapMember(c.Actor, actor)
+	}
+
+	swapState := BrowseListStateCreate(
+		c.Stack, x+24, y+24, itemsSelectionWidth, 100, "SWAP",
+		func(item interface{}) {
+			//onFocus do nothing
+		},
+		OnExit,
+		c.SwapCandidates(),
+		OnSelection,
+		OnRenderItem,
+	)
+	c.Stack.Push(swapState)
+}
+
+//OnLimitAction targets of the Actor's Limit are confirmed like a special's
+func (c *CombatChoiceState) OnLimitAction() {
+	def := world.LimitsDB[c.Actor.Limit]
+	c.Selection.HideCursor()
+
+	state := CombatTargetStateCreate(c.CombatState, CombatChoiceParams{
+		OnSelect: func(targets []*combat.Actor) {
+			c.Stack.Pop() // target state
+			c.Stack.Pop() // action state
+
+			queue := c.CombatState.EventQueue
+			event := CELimitCreate(c.CombatState, c.Actor, targets, def)
+			tp := event.TimePoints(queue)
+			queue.Add(event, tp)
+		},
+		OnExit: func() {
+			c.Selection.ShowCursor()
+		},
+		SwitchSides:     def.Target.SwitchSides,
+		DefaultSelector: CombatSelectorMap[def.Target.Selector],
+		TargetType:      def.Target.Type,
+	})
+	c.Stack.Push(state)
+}
+
+//OnTechAction lists the techs, the tip tells who takes part
+func (c *CombatChoiceState) OnTechAction() {
+	actor := c.Actor
+	queue := c.CombatState.EventQueue
+	ready := func(a *combat.Actor) bool {
+		return a == actor || queue.TurnReady(a)
+	}
+
+	itemsSelectionWidth := 150.0
+	x := c.Selection.X - (itemsSelectionWidth / 2)
+	y := c.Selection.Y - (itemsSelectionWidth / 2)
+	c.Selection.HideCursor()
+
+	OnFocus := func(item interface{}) {
+		tech := reflect.ValueOf(item).Interface().(world.Tech)
+		members, _ := combat.TechParticipants(tech, c.CombatState.Actors[party], ready)
+		var names []string
+		for _, v := range members {
+			names = append(names, v.Name)
+		}
+		c.CombatState.ShowTip(strings.Join(names, " & "))
+	}
+
+	OnExit := func() {
+		c.CombatState.HideTip()
+		c.Selection.ShowCursor()
+	}
+
+	OnRenderItem := func(a ...interface{}) {
+		//renderer pixel.Target, x, y float64, tech world.Tech
+		renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+		x := reflect.ValueOf(a[1]).Interface().(float64)
+		y := reflect.ValueOf(a[2]).Interface().(float64)
+		tech := reflect.ValueOf(a[3]).Interface().(world.Tech)
+
+		textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+		fmt.Fprintf(textBase, "%s (%v)", tech.Name, actor.MpCost(tech.MpCost))
+		textBase.Draw(renderer, pixel.IM)
+	}
+
+	OnSelection := func(selection *BrowseListState, index int, techI interface{}) {
+		tech := reflect.ValueOf(techI).Interface().(world.Tech)
+		members, ok := combat.TechParticipants(tech, c.CombatState.Actors[party], ready)
+		if !ok {
+			return
+		}
+		selection.Hide()
+		c.Hide()
+
+		targeter := CombatTargetStateCreate(c.CombatState, CombatChoiceParams{
+			OnSelect: func(targets []*combat.Actor) {
+				c.Stack.Pop() // target state
+				c.Stack.Pop() // tech browse state
+				c.Stack.Pop() // action state
+
+				//the partners give up their next turn
+				for _, v := range members {
+					if v != actor {
+						queue.RemoveEventsOwnedBy(v)
+					}
+				}
+				event := CETechCreate(c.CombatState, actor, members, targets, tech)
+				tp := event.TimePoints(queue)
+				queue.Add(event, tp)
+			},
+			OnExit: func() {
+				selection.Show()
+				c.Show()
+			},
+			SwitchSides:     tech.Target.SwitchSides,
+			DefaultSelector: CombatSelectorMap[tech.Target.Selector],
+			TargetType:      tech.Target.Type,
+		})
+		c.Stack.Push(targeter)
+	}
+
+	techsState := BrowseListStateCreate(
+		c.Stack, x+24, y+24, itemsSelectionWidth, 100, "TECH",
+		OnFocus,
+		OnExit,
+		c.AvailableTechs(),
+		OnSelection,
+		OnRenderItem,
+	)
+	c.Stack.Push(techsState)
+}
+
 func (c *CombatChoiceState) OnSpecialAction() {
 	actor := c.Actor
 
@@ -270,9 +478,10 @@ func (c *CombatChoiceState) OnSpecialAction() {
 			panic(fmt.Sprintf("Key '%s' not found in SpecialsDB", elementStr))
 		}
 
-		txtWithCost := fmt.Sprintf("%s (%v)", def.Name, def.MpCost)
+		cost := actor.MpCost(def.MpCost)
+		txtWithCost := fmt.Sprintf("%s (%v)", def.Name, cost)
 
-		if mpNow >= def.MpCost {
+		if mpNow >= cost {
 			color_ = utilz.HexToColor("#ffffff")
 		}
 
@@ -291,7 +500,7 @@ func (c *CombatChoiceState) OnSpecialAction() {
 		}
 
 		mpNow := actor.Stats.Get("MpNow")
-		if mpNow < def.MpCost {
+		if mpNow < actor.MpCost(def.MpCost) {
 			return //not enough mp
 		}
 
@@ -347,9 +556,10 @@ func (c *CombatChoiceState) OnMagicAction() {
 			panic(fmt.Sprintf("Key '%s' not found in SpellsDB", elementStr))
 		}
 
-		txtWithCost := fmt.Sprintf("%s (%v)", def.Name, def.MpCost)
+		cost := actor.MpCost(def.MpCost)
+		txtWithCost := fmt.Sprintf("%s (%v)", def.Name, cost)
 
-		if mpNow >= def.MpCost {
+		if mpNow >= cost {
 			color_ = utilz.HexToColor("#ffffff")
 		}
 
@@ -368,7 +578,7 @@ func (c *CombatChoiceState) OnMagicAction() {
 		}
 
 		mpNow := actor.Stats.Get("MpNow")
-		if mpNow < def.MpCost {
+		if mpNow < actor.MpCost(def.MpCost) {
 			return //not enough mp
 		}
 
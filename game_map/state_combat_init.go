@@ -45,7 +45,10 @@ type CombatState struct {
 	BackgroundBounds pixel.Rect
 	Pos              pixel.Vec
 	Layout           gui.Layout
-	LayoutMap        map[string][][]pixel.Vec
+	Formation        combat.CombatFormation
+	Start            combat.StartCondition
+	Mode             combat.BattleMode
+	Formula          FormulaT //rule profile of this fight, see RuleProfiles
 	Actors           map[string][]*combat.Actor
 	Characters       map[string][]*Character
 	DeathList        []*Character
@@ -73,6 +76,10 @@ type CombatState struct {
 	Fled,
 	CanFlee bool
 	OnDieCallback, OnWinCallback func()
+	Buffs                        map[*combat.Actor][]world.ModSource //removed once combat is over
+	HadTurn                      map[*combat.Actor]bool              //see world.PassiveFirstStrike
+	PartyDamage                  float64                             //HP lost by the party, see combat.CombatData.NoDamage
+	SwappedOut                   []*combat.Actor                     //left the fight for a reserve member, still earn a full share
 }
 
 type PanelTitle struct {
@@ -80,7 +87,7 @@ type PanelTitle struct {
 	x, y float64
 }
 type BarStats struct {
-	HP, MP gui.ProgressBarIMD
+	HP, MP, ATB, Limit gui.ProgressBarIMD
 }
 
 func CombatStateCreate(state *gui.StateStack, win *pixelgl.Window, def CombatDef) *CombatState {
@@ -122,11 +129,23 @@ func CombatStateCreate(state *gui.StateStack, win *pixelgl.Window, def CombatDef
 		CanFlee:       def.CanFlee,
 		OnWinCallback: def.OnWin,
 		OnDieCallback: def.OnDie,
+		Buffs:         make(map[*combat.Actor][]world.ModSource),
+		HadTurn:       make(map[*combat.Actor]bool),
 	}
 
-	c.LayoutMap = combatLayout
+	c.Formation = def.Formation
+	gWorld := reflect.ValueOf(state.Globals["world"]).Interface().(*combat.WorldExtended)
+	c.Mode = gWorld.BattleMode
+	c.Formula = RuleProfileFor(def.Rules, gWorld.Difficulty)
+	c.EventQueue.RealTime = c.Mode.IsRealTime()
+	c.EventQueue.Hold = c.holdEvent
+	c.Start = def.Start
+	if c.Start == combat.StartNormal {
+		c.Start = combat.RollStartCondition(combat.DefaultStartRules, def.Trigger, c.Actors[party], c.Actors[enemies], utilz.RandFloat(0, 1))
+	}
 	c.CreateCombatCharacters(party)
 	c.CreateCombatCharacters(enemies)
+	c.PlaceCombatants()
 
 	c.Panels = []gui.Panel{
 		layout.CreatePanel("left"),
@@ -185,6 +204,16 @@ func CombatStateCreate(state *gui.StateStack, win *pixelgl.Window, def CombatDef
 	c.StatsList.SetPosition(x, y)
 	c.StatsList.HideCursor()
 
+	if c.Start != combat.StartNormal {
+		c.InternalStack.Push(StoryboardCreate(c.InternalStack, win, []interface{}{
+			RunFunction(func() {
+				c.ShowNotice(c.Start.String())
+			}),
+			Wait(1.5),
+			RunFunction(c.HideNotice),
+		}, false))
+	}
+
 	return c
 }
 
@@ -208,9 +237,30 @@ func (c *CombatState) BuildBars(actor *combat.Actor) {
 		c.imd,
 	)
 
+	atbBar := gui.ProgressBarIMDCreate(
+		0, 0,
+		0, 1,
+		"#7f7575",
+		"#ffd700",
+		2, 100,
+		c.imd,
+	)
+
+	limitBar := gui.ProgressBarIMDCreate(
+		0, 0,
+		actor.LimitGauge,
+		combat.LimitGaugeMax,
+		"#7f7575",
+		"#ff8c00",
+		2, 100,
+		c.imd,
+	)
+
 	c.Bars[actor] = BarStats{
-		HP: hpBar,
-		MP: mpBar,
+		HP:    hpBar,
+		MP:    mpBar,
+		ATB:   atbBar,
+		Limit: limitBar,
 	}
 }
 
@@ -245,20 +295,31 @@ func (c *CombatState) Update(dt float64) bool {
 		fx.Update(dt)
 	}
 
+	if c.EventQueue.RealTime {
+		c.closeMenusOfKOed()
+	}
 	if len(c.InternalStack.States) != 0 && c.InternalStack.Top() != nil {
 		c.InternalStack.Update(dt)
-		return true
+		if !c.TimeRuns() {
+			return true
+		}
 	}
 	if !c.IsFinishing {
+		c.EventQueue.Tick(dt)
 		c.EventQueue.Update()
+		for actor := range c.Buffs {
+			c.ExpireBuffs(actor, actor.Stats.TimePassed(dt))
+		}
 		c.AddTurns(c.Actors[party])
 		c.AddTurns(c.Actors[enemies])
 
 		if c.PartyWins() || c.HasPartyFled() {
 			c.EventQueue.Clear()
+			c.RemoveBuffs()
 			c.OnWin()
 		} else if c.EnemyWins() {
 			c.EventQueue.Clear()
+			c.RemoveBuffs()
 			c.OnLose()
 		}
 	}
@@ -266,6 +327,65 @@ func (c *CombatState) Update(dt float64) bool {
 	return false
 }
 
+//TimeRuns while menus are open in ATB modes, in combat.BattleWait only until a submenu
+//opens. Anything else on the InternalStack e.g. an action's Storyboard stops time
+func (c *CombatState) TimeRuns() bool {
+	states := c.InternalStack.States
+	switch c.Mode {
+	case combat.BattleActive:
+		for _, v := range states {
+			if !isCombatMenu(v) {
+				return false
+			}
+		}
+		return true
+	case combat.BattleWait:
+		if len(states) != 1 {
+			return false
+		}
+		_, ok := states[0].(*CombatChoiceState)
+		return ok
+	}
+	return false
+}
+
+func isCombatMenu(state gui.StackInterface) bool {
+	switch state.(type) {
+	case *CombatChoiceState, *CombatTargetState, *BrowseListState:
+		return true
+	}
+	return false
+}
+
+//holdEvent party turns wait for the open menu to close, one party member chooses at a time
+func (c *CombatState) holdEvent(event CombatEvent) bool {
+	_, isTurn := event.(*CETurn)
+	return isTurn && len(c.InternalStack.States) > 0 && c.IsPartyMember(event.Owner())
+}
+
+//closeMenusOfKOed an enemy acting in real time can KO the party member choosing an action
+func (c *CombatState) closeMenusOfKOed() {
+	if c.SelectedActor == nil || !c.SelectedActor.IsKOed() {
+		return
+	}
+	for len(c.InternalStack.States) > 0 && isCombatMenu(*c.InternalStack.Top()) {
+		c.InternalStack.Pop()
+	}
+}
+
+//ATBGauge how close actor is to its next turn, full while it chooses or takes an action
+func (c *CombatState) ATBGauge(actor *combat.Actor) float64 {
+	for _, v := range c.EventQueue.Queue {
+		if turn, ok := v.(*CETurn); ok && turn.Owner() == actor {
+			return combat.ATBGauge(turn.CountDown(), turn.TimePoints(c.EventQueue))
+		}
+	}
+	if c.SelectedActor == actor || c.EventQueue.ActorHasEvent(actor) {
+		return 1
+	}
+	return 0
+}
+
 func (c CombatState) Render(renderer *pixelgl.Window) {
 	c.Background.Draw(renderer, pixel.IM.Moved(c.Pos))
 
@@ -340,64 +460,129 @@ func (c CombatState) Render(renderer *pixelgl.Window) {
 func (c *CombatState) HandleInput(win *pixelgl.Window) {
 }
 
+//CreateCombatCharacters see PlaceCombatants for their positions
 func (c *CombatState) CreateCombatCharacters(key string) {
-	actorsList := c.Actors[key]
-	layout := c.LayoutMap[key][len(actorsList)-1]
+	for _, v := range c.Actors[key] {
+		char := c.createCombatCharacter(v)
 
-	for k, v := range actorsList {
-		charDef, ok := CharacterDefinitions[v.Id]
-		if !ok {
-			panic(fmt.Sprintf("Id '%s' Not found in CharacterDefinitions", v.Id))
-		}
+		// Change to standby because it's combat time
+		animName := csStandby
+		char.Controller.Change(csStandby, animName)
 
-		if charDef.CombatEntityDef.Texture != "" {
-			charDef.EntityDef = charDef.CombatEntityDef
+		c.Characters[key] = append(c.Characters[key], char)
+	}
+}
+
+//PlaceCombatants positions every character with combat.CombatLayout
+func (c *CombatState) PlaceCombatants() {
+	layoutActors := func(key string) []combat.LayoutActor {
+		var list []combat.LayoutActor
+		for _, v := range c.Actors[key] {
+			entity := c.ActorCharMap[v].Entity
+			list = append(list, combat.LayoutActor{
+				Size: pixel.V(entity.Width, entity.Height),
+				Back: v.Row == combat.BackRow,
+			})
 		}
+		return list
+	}
+	screen := pixel.V(c.win.Bounds().W(), c.win.Bounds().H())
+	partySlots, enemySlots := combat.CombatLayout(c.Formation, layoutActors(party), layoutActors(enemies), screen)
+	if c.Start == combat.StartBackAttack {
+		combat.MirrorSlots(partySlots)
+		combat.MirrorSlots(enemySlots)
+	}
 
-		var char *Character
-		char = CharacterCreate(
-			charDef,
-			map[string]func() state_machine.State{
-				csStandby: func() state_machine.State {
-					return CSStandByCreate(char, c)
-				},
-				csNpcStand: func() state_machine.State {
-					return NPCStandCombatStateCreate(char, c)
-				},
-				csRunanim: func() state_machine.State {
-					return CSRunAnimCreate(char, c)
-				},
-				csHurt: func() state_machine.State {
-					return CSHurtCreate(char, c)
-				},
-				csMove: func() state_machine.State {
-					return CSMoveCreate(char, c)
-				},
-				csEnemyDie: func() state_machine.State {
-					return CSEnemyDieCreate(char, c)
-				},
-			},
-		)
+	slots := map[string][]combat.LayoutSlot{party: partySlots, enemies: enemySlots}
+	for key, list := range slots {
+		for i, slot := range list {
+			char := c.ActorCharMap[c.Actors[key][i]]
+			char.Entity.X = slot.Pos.X
+			char.Entity.Y = slot.Pos.Y
+			if slot.Flipped {
+				char.Facing = CharacterFacingDirection[1] //towards the other side
+			} else {
+				char.Facing = CharacterFacingDirection[3]
+			}
+		}
+	}
+}
 
-		c.ActorCharMap[v] = char
+func (c *CombatState) createCombatCharacter(v *combat.Actor) *Character {
+	charDef, ok := CharacterDefinitions[v.Id]
+	if !ok {
+		panic(fmt.Sprintf("Id '%s' Not found in CharacterDefinitions", v.Id))
+	}
 
-		pos := layout[k]
+	if charDef.CombatEntityDef.Texture != "" {
+		charDef.EntityDef = charDef.CombatEntityDef
+	}
 
-		// Combat positions are 0 - 1
-		// Need scaling to the screen size.
-		x := pos.X * c.win.Bounds().W()
-		y := pos.Y * c.win.Bounds().H()
+	var char *Character
+	char = CharacterCreate(
+		charDef,
+		map[string]func() state_machine.State{
+			csStandby: func() state_machine.State {
+				return CSStandByCreate(char, c)
+			},
+			csNpcStand: func() state_machine.State {
+				return NPCStandCombatStateCreate(char, c)
+			},
+			csRunanim: func() state_machine.State {
+				return CSRunAnimCreate(char, c)
+			},
+			csHurt: func() state_machine.State {
+				return CSHurtCreate(char, c)
+			},
+			csMove: func() state_machine.State {
+				return CSMoveCreate(char, c)
+			},
+			csEnemyDie: func() state_machine.State {
+				return CSEnemyDieCreate(char, c)
+			},
+		},
+	)
 
-		char.Entity.X = x
-		char.Entity.Y = y
+	c.ActorCharMap[v] = char
+	return char
+}
 
-		// Change to standby because it's combat time
-		animName := csStandby
-		char.Controller.Change(csStandby, animName)
+//ChangeRow moves actor to the other row, it ends the turn of actor
+func (c *CombatState) ChangeRow(actor *combat.Actor) {
+	actor.Row = actor.Row.Toggle()
+	c.PlaceCombatants()
+	c.AddTextEffect(actor, fmt.Sprintf("%s Row", actor.Row), 1)
+}
 
-		c.Characters[key] = append(c.Characters[key], char)
+//SwapMember reserve member in takes the formation slot of out, in the
+//World party too. It ends the turn of out
+func (c *CombatState) SwapMember(out, in *combat.Actor) {
+	index := -1
+	for i, v := range c.Actors[party] {
+		if v == out {
+			index = i
+		}
+	}
+	world_ := reflect.ValueOf(c.GameState.Globals["world"]).Interface().(*combat.WorldExtended)
+	if index == -1 || !world_.Party.Swap(out.Id, in.Id) {
+		return
 	}
 
+	char := c.createCombatCharacter(in)
+	char.Controller.Change(csStandby, csStandby)
+	delete(c.ActorCharMap, out)
+
+	c.Actors[party][index] = in
+	c.Characters[party][index] = char
+	c.PlaceCombatants()
+	c.PartyList.DataI[index] = in
+	c.StatsList.DataI[index] = in
+	delete(c.Bars, out)
+	c.BuildBars(in)
+
+	c.EventQueue.RemoveEventsOwnedBy(out)
+	c.SwappedOut = append(c.SwappedOut, out)
+	c.AddTextEffect(in, fmt.Sprintf("%s steps in", in.Name), 1)
 }
 
 func (c *CombatState) OnPartyMemberSelect(index int, str interface{}) {
@@ -451,6 +636,12 @@ func (c *CombatState) RenderPartyStats(args ...interface{}) {
 	bars.HP.SetValue(stats.Get("HpNow"))
 	bars.HP.Render(renderer)
 
+	if c.EventQueue.RealTime {
+		bars.ATB.SetPosition(x+barOffset, y-4)
+		bars.ATB.SetValue(c.ATBGauge(actor))
+		bars.ATB.Render(renderer)
+	}
+
 	c.DrawHP(renderer, x, y, actor)
 
 	x = x + c.StatsYCol
@@ -460,10 +651,16 @@ func (c *CombatState) RenderPartyStats(args ...interface{}) {
 	bars.MP.SetPosition(x+barOffset*0.7, y)
 	bars.MP.SetValue(mpNow)
 	bars.MP.Render(renderer)
+
+	if actor.Limit != "" {
+		bars.Limit.SetPosition(x+barOffset*0.7, y-4)
+		bars.Limit.SetValue(actor.LimitGauge)
+		bars.Limit.Render(renderer)
+	}
 }
 
 func (c *CombatState) DrawHP(renderer pixel.Target, x, y float64, actor *combat.Actor) {
-	hp, max := actor.Stats.Get("HpNow"), actor.Stats.Get("HpMax")
+	hp, max := actor.Stats.Value(world.StatHpNow), actor.Stats.Value(world.StatHpMax)
 	percentHealth := hp / max
 
 	txtColor := utilz.HexToColor("#ffffff")
@@ -480,7 +677,7 @@ func (c *CombatState) DrawHP(renderer pixel.Target, x, y float64, actor *combat.
 }
 
 func (c *CombatState) DrawMP(renderer pixel.Target, x, y float64, actor *combat.Actor) {
-	mpNow := actor.Stats.Get("MpNow")
+	mpNow := actor.Stats.Value(world.StatMpNow)
 	mpNowStr := fmt.Sprintf("%v", mpNow)
 	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
 	fmt.Fprintln(textBase, mpNowStr)
@@ -534,6 +731,9 @@ func (c *CombatState) HandlePartyDeath() {
 				//party player can be revived
 				character.Controller.Change(csRunanim, csDeath, false)
 				c.EventQueue.RemoveEventsOwnedBy(actor)
+				for _, ally := range c.Actors[party] {
+					ally.FillLimit(combat.LimitAllyKOFill)
+				}
 			}
 		}
 	}
@@ -582,6 +782,10 @@ func (c *CombatState) ApplyDamage(target *combat.Actor, damage float64, isCritic
 	stats.Set("HpNow", math.Max(0, hp))
 	hpAfterDamage := stats.Get("HpNow")
 	logrus.Info(target.Name, " HP now ", hpAfterDamage)
+	if damage > 0 && c.IsPartyMember(target) {
+		c.PartyDamage += damage
+	}
+	target.FillLimitFromDamage(damage)
 
 	// Change actor's character to hurt state
 	character := c.ActorCharMap[target]
@@ -607,6 +811,65 @@ func (c *CombatState) ApplyDamage(target *combat.Actor, damage float64, isCritic
 	c.HandleDeath()
 }
 
+//ApplyElementDamage shows how the Affinity of target took an attack of element,
+//damage already has the Affinity applied e.g. by ApplyResistance
+func (c *CombatState) ApplyElementDamage(target *combat.Actor, damage float64, isCritical bool, element string) {
+	affinity := target.Affinity(element)
+	// reflected spells never reach here, weapons hit reflecting targets as normal
+	if text := affinity.CombatText(); text != "" && affinity != combat.AffinityReflect {
+		c.AddTextEffect(target, text, 2)
+	}
+	if damage < 0 {
+		c.AbsorbDamage(target, -damage)
+		return
+	}
+	c.ApplyDamage(target, damage, isCritical)
+}
+
+//AbsorbDamage target heals by amount instead of losing it, see combat.AffinityAbsorb
+func (c *CombatState) AbsorbDamage(target *combat.Actor, amount float64) {
+	_, _, entity := StatsCharEntity(c, target)
+	if target.RestoreHP(amount) > 0 {
+		AddTextNumberEffect(c, entity, amount, "#00ff45")
+	}
+}
+
+//ReflectTarget a random conscious actor on the side of caster, nil if none
+func (c *CombatState) ReflectTarget(caster *combat.Actor) *combat.Actor {
+	return combat.ReflectTarget(c.SideOf(caster))
+}
+
+//AddBuff modifies target stats until buff runs out or the combat is over,
+//returns false if target is KOed
+func (c *CombatState) AddBuff(target *combat.Actor, source world.ModSource, buff world.Modifier) bool {
+	if !target.ApplyBuff(source, buff) {
+		return false
+	}
+	c.Buffs[target] = append(c.Buffs[target], source)
+	return true
+}
+
+func (c *CombatState) RemoveBuffs() {
+	for actor, sources := range c.Buffs {
+		for _, source := range sources {
+			actor.Stats.RemoveSource(source)
+		}
+	}
+	c.Buffs = make(map[*combat.Actor][]world.ModSource)
+}
+
+//ExpireBuffs shows which of the modifiers that ran out on actor were buffs
+func (c *CombatState) ExpireBuffs(actor *combat.Actor, expired []world.AppliedMod) {
+	if _, ok := c.ActorCharMap[actor]; !ok {
+		return //died
+	}
+	for _, mod := range expired {
+		if mod.Modifier.Name != "" {
+			c.AddTextEffect(actor, fmt.Sprintf("%s wore off", mod.Modifier.Name), 1)
+		}
+	}
+}
+
 func (c *CombatState) OnFlee() {
 	c.Fled = true
 }
@@ -676,17 +939,25 @@ func (c *CombatState) OnLose() {
 	//c.GameState.Push(gameOverState)
 }
 
-func (c *CombatState) CalcCombatData() CombatData {
-	drop := CombatData{
-		XP:   0,
-		Gold: 0,
-		Loot: make([]world.ItemIndex, 0),
+func (c *CombatState) CalcCombatData() combat.CombatData {
+	drop := combat.CombatData{
+		XP:       0,
+		Gold:     0,
+		Loot:     make([]world.ItemIndex, 0),
+		NoDamage: c.PartyDamage == 0,
+	}
+	for _, v := range c.Actors[party] {
+		drop.Fought = append(drop.Fought, v.Id)
+	}
+	for _, v := range c.SwappedOut {
+		drop.Fought = append(drop.Fought, v.Id)
 	}
 
 	lootDict := make(map[int]int) //itemId = count
 
 	for _, v := range c.Loot {
 		drop.XP += v.XP
+		drop.AP += v.AP
 		drop.Gold += v.Gold
 
 		for _, itemId := range v.Always {
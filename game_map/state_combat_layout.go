@@ -1,12 +1,11 @@
 package game_map
 
 import (
-	"github.com/faiface/pixel"
 	"github.com/steelx/go-rpg-cgm/combat"
 )
 
 type Actors struct {
-	Party   []*combat.Actor
+	Party   []*combat.Actor //combat.Party.Active in formation order
 	Enemies []*combat.Actor
 }
 
@@ -20,6 +19,10 @@ type CombatDef struct {
 	Actors       Actors
 	Characters   CombatCharacters
 	CanFlee      bool
+	Formation    combat.CombatFormation
+	Trigger      combat.EncounterTrigger
+	Start        combat.StartCondition //forces how a scripted fight opens, StartNormal rolls one
+	Rules        string                //RuleProfiles key, "" follows the Difficulty setting
 	OnWin, OnDie func()
 }
 
@@ -27,57 +30,3 @@ const (
 	enemies = "enemies"
 	party   = "party"
 )
-
-//All positions are in the range 0 - 1. Each number is a percentage of screen width and
-//height offset from the center of the screen.
-var combatLayout = map[string][][]pixel.Vec{
-	party: {
-		{
-			pixel.V(0.25, -0.056),
-		},
-		{
-			pixel.V(0.23, 0.024),
-			pixel.V(0.27, -0.136),
-		},
-		{
-			pixel.V(0.23, 0.024),
-			pixel.V(0.25, -0.056),
-			pixel.V(0.27, -0.136),
-		},
-	},
-	enemies: {
-		{
-			pixel.V(-0.25, -0.056),
-		},
-		{
-			pixel.V(-0.23, 0.024),
-			pixel.V(-0.27, -0.136),
-		},
-		{
-			pixel.V(-0.21, -0.056),
-			pixel.V(-0.23, 0.024),
-			pixel.V(-0.27, -0.136),
-		},
-		{
-			pixel.V(-0.18, -0.056),
-			pixel.V(-0.23, 0.056),
-			pixel.V(-0.25, -0.056),
-			pixel.V(-0.27, -0.168),
-		},
-		{
-			pixel.V(-0.28, 0.032),
-			pixel.V(-0.3, -0.056),
-			pixel.V(-0.32, -0.144),
-			pixel.V(-0.2, 0.004),
-			pixel.V(-0.24, -0.116),
-		},
-		{
-			pixel.V(-0.28, 0.032),
-			pixel.V(-0.3, -0.056),
-			pixel.V(-0.32, -0.144),
-			pixel.V(-0.16, 0.032),
-			pixel.V(-0.205, -0.056),
-			pixel.V(-0.225, -0.144),
-		},
-	},
-}
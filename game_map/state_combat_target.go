@@ -1,6 +1,9 @@
 package game_map
 
 import (
+	"math"
+	"sort"
+
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
 	"github.com/steelx/go-rpg-cgm/combat"
@@ -126,58 +129,99 @@ func (t CombatTargetState) GetIndex(listI []*combat.Actor, item *combat.Actor) i
 	return 0
 }
 
+//Left nearest target to the left, the whole side further left for CombatTargetTypeSIDE
 func (t *CombatTargetState) Left() {
-	if !t.CanSwitchSide || !t.CombatState.IsPartyMember(t.Targets[0]) {
-		return
-	}
-	if t.SelectType == world.CombatTargetTypeONE {
-		t.Targets = []*combat.Actor{t.Enemies[0]}
-	}
-	if t.SelectType == world.CombatTargetTypeSIDE {
-		t.Targets = t.Enemies
-	}
+	t.moveSideways(-1)
 }
 
 func (t *CombatTargetState) Right() {
-	if !t.CanSwitchSide || !t.CombatState.IsPartyMember(t.Targets[0]) {
+	t.moveSideways(1)
+}
+
+func (t *CombatTargetState) moveSideways(dir float64) {
+	if t.SelectType == world.CombatTargetTypeSIDE {
+		if !t.CanSwitchSide {
+			return
+		}
+		other := t.Enemies
+		if !t.CombatState.IsPartyMember(t.Targets[0]) {
+			other = t.Party
+		}
+		if len(other) > 0 && (t.centerX(other)-t.centerX(t.Targets))*dir > 0 {
+			t.Targets = other
+		}
 		return
 	}
-	if t.SelectType == world.CombatTargetTypeONE {
-		t.Targets = []*combat.Actor{t.Party[0]}
+	if t.SelectType != world.CombatTargetTypeONE {
+		return
 	}
-	if t.SelectType == world.CombatTargetTypeSIDE {
-		t.Targets = t.Party
+
+	selected := t.Targets[0]
+	candidates := t.GetActorList(selected)
+	if t.CanSwitchSide {
+		candidates = append(append([]*combat.Actor{}, t.Party...), t.Enemies...)
+	}
+	from := t.position(selected)
+	var best *combat.Actor
+	bestDistance := math.Inf(1)
+	for _, v := range candidates {
+		pos := t.position(v)
+		if (pos.X-from.X)*dir <= 0 {
+			continue
+		}
+		if d := pos.Sub(from).Len(); d < bestDistance {
+			best, bestDistance = v, d
+		}
+	}
+	if best != nil {
+		t.Targets = []*combat.Actor{best}
 	}
 }
 
+//Up target above on the same side, wraps around to the bottom
 func (t *CombatTargetState) Up() {
+	t.moveVertically(-1)
+}
+
+func (t *CombatTargetState) Down() {
+	t.moveVertically(1)
+}
+
+func (t *CombatTargetState) moveVertically(step int) {
 	if t.SelectType != world.CombatTargetTypeONE {
 		return
 	}
 
 	selected := t.Targets[0]
-	side := t.GetActorList(selected)
+	side := t.ByPosition(t.GetActorList(selected))
 	index := t.GetIndex(side, selected)
 
-	index = index + 1
-	if index >= len(side) {
-		index = 0
-	}
+	index = (index + step + len(side)) % len(side)
 	t.Targets = []*combat.Actor{side[index]}
 }
 
-func (t *CombatTargetState) Down() {
-	if t.SelectType != world.CombatTargetTypeONE {
-		return
-	}
+//ByPosition side from top to bottom as placed by combat.CombatLayout, then left to right
+func (t CombatTargetState) ByPosition(side []*combat.Actor) []*combat.Actor {
+	sorted := append([]*combat.Actor{}, side...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := t.position(sorted[i]), t.position(sorted[j])
+		if a.Y != b.Y {
+			return a.Y > b.Y
+		}
+		return a.X < b.X
+	})
+	return sorted
+}
 
-	selected := t.Targets[0]
-	side := t.GetActorList(selected)
-	index := t.GetIndex(side, selected)
+func (t CombatTargetState) position(actor *combat.Actor) pixel.Vec {
+	entity := t.CombatState.ActorCharMap[actor].Entity
+	return pixel.V(entity.X, entity.Y)
+}
 
-	index = index - 1
-	if index == -1 {
-		index = len(side) - 1
+func (t CombatTargetState) centerX(actors []*combat.Actor) float64 {
+	x := 0.0
+	for _, v := range actors {
+		x += t.position(v).X
 	}
-	t.Targets = []*combat.Actor{side[index]}
+	return x / float64(len(actors))
 }
@@ -0,0 +1,140 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/state_machine"
+	"golang.org/x/image/font/basicfont"
+)
+
+//settings listed in the ConfigMenuState
+const (
+	configBattleMode = "Battle Mode"
+	configDifficulty = "Difficulty"
+)
+
+var battleModeDescriptions = map[combat.BattleMode]string{
+	combat.BattleTurnBased: "Time stops while choosing actions.",
+	combat.BattleActive:    "Enemies keep acting while menus are open.",
+	combat.BattleWait:      "Time stops while choosing a target or item.",
+}
+
+//ConfigMenuState game settings, choose one to change it
+type ConfigMenuState struct {
+	parent       *InGameMenuState
+	win          *pixelgl.Window
+	Layout       gui.Layout
+	StateMachine *state_machine.StateMachine
+	Panels       []gui.Panel
+	SettingsMenu *gui.SelectionMenu
+}
+
+func ConfigMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *ConfigMenuState {
+	layout := gui.LayoutCreate(0, 0, win)
+	layout.Contract("screen", 118, 40)
+	layout.SplitHorz("screen", "title", "bottom", 0.12, 2)
+	layout.SplitHorz("bottom", "desc", "settings", 0.14, 2)
+
+	return &ConfigMenuState{
+		win:          win,
+		parent:       parent,
+		StateMachine: parent.StateMachine,
+		Layout:       layout,
+		Panels: []gui.Panel{
+			layout.CreatePanel("title"),
+			layout.CreatePanel("desc"),
+			layout.CreatePanel("settings"),
+		},
+	}
+}
+
+func (f ConfigMenuState) IsFinished() bool {
+	return true
+}
+
+func (f *ConfigMenuState) Enter(data ...interface{}) {
+	settingsMenu := gui.SelectionMenuCreate(26, 0, 300,
+		[]string{configBattleMode, configDifficulty},
+		false,
+		pixel.V(0, 0),
+		f.OnSettingSelect,
+		f.RenderSetting,
+	)
+	f.SettingsMenu = &settingsMenu
+}
+
+func (f *ConfigMenuState) OnSettingSelect(index int, settingI interface{}) {
+	setting := reflect.ValueOf(settingI).Interface().(string)
+	if setting == configBattleMode {
+		f.parent.World.BattleMode = f.parent.World.BattleMode.Next()
+	} else if setting == configDifficulty {
+		f.parent.World.Difficulty = NextRuleProfile(f.parent.World.Difficulty)
+	}
+}
+
+func (f ConfigMenuState) RenderSetting(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, setting string
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	setting := reflect.ValueOf(a[3]).Interface().(string)
+
+	var value interface{}
+	if setting == configBattleMode {
+		value = f.parent.World.BattleMode
+	} else if setting == configDifficulty {
+		value = f.parent.World.Difficulty
+	}
+
+	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+	fmt.Fprintf(textBase, "%-14s %v", setting, value)
+	textBase.Draw(renderer, pixel.IM)
+}
+
+func (f ConfigMenuState) Render(win *pixelgl.Window) {
+	for _, v := range f.Panels {
+		v.Draw(win)
+	}
+
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+	titleX := f.Layout.Left("title") + 16
+	titleY := f.Layout.MidY("title")
+	textBase := text.New(pixel.V(titleX, titleY), basicAtlas)
+	fmt.Fprintln(textBase, frontMenuOrder[config])
+	textBase.Draw(win, pixel.IM)
+
+	descX := f.Layout.Left("desc") + 20
+	descY := f.Layout.MidY("desc")
+	textBase = text.New(pixel.V(descX, descY), basicAtlas)
+	setting := reflect.ValueOf(f.SettingsMenu.SelectedItem()).Interface().(string)
+	if setting == configBattleMode {
+		fmt.Fprintln(textBase, battleModeDescriptions[f.parent.World.BattleMode])
+	} else if setting == configDifficulty {
+		fmt.Fprintln(textBase, RuleProfileFor(f.parent.World.Difficulty).Rules.Description)
+	}
+	textBase.Draw(win, pixel.IM)
+
+	settingsX := f.Layout.Left("settings") - 6
+	settingsY := f.Layout.Top("settings") - 24
+	f.SettingsMenu.SetPosition(settingsX, settingsY)
+	f.SettingsMenu.Render(win)
+}
+
+func (f ConfigMenuState) Exit() {
+
+}
+
+func (f *ConfigMenuState) Update(dt float64) {
+	if f.win.JustReleased(pixelgl.KeyBackspace) || f.win.JustReleased(pixelgl.KeyEscape) {
+		f.StateMachine.Change("frontmenu", nil)
+		return
+	}
+	f.SettingsMenu.HandleInput(f.win)
+}
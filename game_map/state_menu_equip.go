@@ -14,8 +14,8 @@ import (
 )
 
 type FilterList struct {
-	slotType world.ItemType
-	list     []world.ItemIndex
+	slot combat.EquipSlot
+	list []world.ItemIndex
 }
 
 type EquipMenuState struct {
@@ -29,8 +29,11 @@ type EquipMenuState struct {
 	equipment                     map[string]int
 	menuIndex                     int
 	actorSummary                  combat.ActorSummary
+	actor                         *combat.Actor //party member, actorSummary holds a copy
 	FilterMenus                   []*gui.SelectionMenu
 	SlotMenu                      *gui.SelectionMenu
+	profileIndex                  int  //combat.EquipProfiles used by Optimize
+	comparing                     bool //show party comparison in stats panel
 }
 
 func EquipMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *EquipMenuState {
@@ -76,12 +79,13 @@ func (e *EquipMenuState) Enter(data ...interface{}) {
 	e.actorSummary = actorSummary
 	e.actorSummary.HideXP()
 	e.equipment = actorSummary.Actor.Equipped
+	e.actor = e.parent.World.Party.Members[actorSummary.Actor.Id]
 
 	e.RefreshFilteredMenus()
 	e.menuIndex = 0
 
 	slotMenu := gui.SelectionMenuCreate(26, 80, 100,
-		e.actorSummary.Actor.ActiveEquipSlots,
+		e.actorSummary.Actor.EquipSlots,
 		false,
 		pixel.V(0, 0),
 		e.OnSelectMenu,
@@ -134,26 +138,63 @@ func (e EquipMenuState) Render(renderer *pixelgl.Window) {
 	itemId := e.GetSelectedItem()
 
 	item := world.ItemsDB[itemId]
-	diffs := e.actorSummary.Actor.PredictStats(slot, item)
 	x := e.Layout.Left("stats") + leftMargin
 	y := e.Layout.Top("stats") - topMargin
 
-	statList := e.actorSummary.Actor.CreateStatNameList()
-	statLabels := e.actorSummary.Actor.CreateStatLabelList()
-	for k, v := range statList {
-		e.DrawStat(renderer, x, y, statLabels[k], v, diffs[v])
-		y = y - 18
+	if e.comparing && e.inInventoryList {
+		e.DrawComparison(renderer, x, y, item)
+	} else {
+		diffs := e.actorSummary.Actor.PredictStats(slot, item)
+		statList := e.actorSummary.Actor.CreateStatNameList()
+		statLabels := e.actorSummary.Actor.CreateStatLabelList()
+		for k, v := range statList {
+			e.DrawStat(renderer, x, y, statLabels[k], v, diffs[v])
+			y = y - 18
+		}
 	}
 
 	// Description panel
 	descX := e.Layout.Left("desc") + leftMargin
-	descY := e.Layout.MidY("desc") - 5
+	descY := e.Layout.MidY("desc") + 4
 	pos = pixel.V(descX, descY)
 	textBase = text.New(pos, basicAtlasAscii)
 	fmt.Fprintln(textBase, item.Description)
+	if e.inInventoryList {
+		fmt.Fprintln(textBase, "C: Compare with party")
+	} else {
+		fmt.Fprintf(textBase, "O: Optimize (%s)  P: Change profile\n", e.Profile().Name)
+	}
+	textBase.Draw(renderer, pixel.IM)
+}
+
+//DrawComparison lists party members by how much they gain from item
+func (e *EquipMenuState) DrawComparison(renderer pixel.Target, x, y float64, item world.Item) {
+	basicAtlasAscii := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+	textBase := text.New(pixel.V(x, y), basicAtlasAscii)
+	fmt.Fprintf(textBase, "%s (%s)\n\n", item.Name, e.Profile().Name)
+
+	for _, v := range e.parent.World.CompareForParty(item, e.Profile()) {
+		if !v.CanUse {
+			fmt.Fprintf(textBase, "%-10s: can't equip\n", v.Actor.Name)
+			continue
+		}
+		fmt.Fprintf(textBase, "%-10s: %-10s %+v\n", v.Actor.Name, v.SlotId, v.Score)
+	}
 	textBase.Draw(renderer, pixel.IM)
 }
 
+//Profile weighting used by Optimize and the party comparison
+func (e EquipMenuState) Profile() combat.EquipProfile {
+	return combat.EquipProfiles[e.profileIndex]
+}
+
+//Optimize equips the best items from inventory for the current Profile
+func (e *EquipMenuState) Optimize() {
+	changes := e.actor.OptimizeEquipment(e.parent.World.Items, e.Profile())
+	e.actor.ApplyEquipment(changes)
+	e.RefreshFilteredMenus()
+}
+
 func (e EquipMenuState) Exit() {
 }
 
@@ -164,6 +205,9 @@ func (e *EquipMenuState) Update(dt float64) {
 			menu.ShowCursor()
 			menu.HandleInput(e.win)
 		}
+		if e.win.JustPressed(pixelgl.KeyC) {
+			e.comparing = !e.comparing
+		}
 		if e.win.JustReleased(pixelgl.KeyEscape) {
 			e.FocusSlotMenu()
 		}
@@ -172,6 +216,13 @@ func (e *EquipMenuState) Update(dt float64) {
 		e.SlotMenu.HandleInput(e.win)
 		e.OnEquipMenuChanged()
 
+		if e.win.JustPressed(pixelgl.KeyO) {
+			e.Optimize()
+		}
+		if e.win.JustPressed(pixelgl.KeyP) {
+			e.profileIndex = (e.profileIndex + 1) % len(combat.EquipProfiles)
+		}
+
 		if e.win.JustPressed(pixelgl.KeyEscape) {
 			e.parent.StateMachine.Change("frontmenu", nil)
 			return
@@ -180,17 +231,15 @@ func (e *EquipMenuState) Update(dt float64) {
 }
 
 func (e *EquipMenuState) RefreshFilteredMenus() {
-	// Get a list of filters by slot type
+	// Get a list of filters by slot
 	// Items will be sorted into these lists
-	slotCount := len(e.actorSummary.Actor.ActiveEquipSlots)
+	slotCount := len(e.actorSummary.Actor.EquipSlots)
 	filterList := make([]*FilterList, slotCount)
 
-	for i, slot := range e.actorSummary.Actor.ActiveEquipSlots {
-		slotType := e.actorSummary.Actor.GetItemTypeBySlotPos(slot)
-
+	for i, slot := range e.actorSummary.Actor.EquipSlots {
 		filterList[i] = &FilterList{
-			slotType: slotType,
-			list:     make([]world.ItemIndex, 0),
+			slot: slot,
+			list: make([]world.ItemIndex, 0),
 		}
 	}
 
@@ -200,7 +249,7 @@ func (e *EquipMenuState) RefreshFilteredMenus() {
 		item := world.ItemsDB[v.Id]
 
 		for _, f := range filterList {
-			if item.ItemType == f.slotType && e.actorSummary.Actor.CanUse(item) {
+			if f.slot.Allows(item.ItemType) && e.actorSummary.Actor.CanUse(item) {
 				f.list = append(f.list, v)
 			}
 		}
@@ -225,8 +274,9 @@ func (e *EquipMenuState) OnDoEquip(index int, itemIdxI interface{}) {
 	itemIdx := itemIdxV.Interface().(world.ItemIndex)
 	item := e.parent.World.Get(itemIdx)
 
-	//equipSlotId := e.actorSummary.Actor.GetEquipSlotIdByItemType(item.ItemType)
-	e.actorSummary.Actor.Equip(e.GetSelectedSlot(), item)
+	if !e.actor.Equip(e.GetSelectedSlot(), item) {
+		return
+	}
 
 	e.RefreshFilteredMenus()
 	e.FocusSlotMenu()
@@ -243,6 +293,9 @@ func (e *EquipMenuState) FocusSlotMenu() {
 //OnSelectMenu get trigger when user selects a Item Slot &
 // then cursor should be visible in Inventory List (Bottom Right)
 func (e *EquipMenuState) OnSelectMenu(i int, wItemTypeI interface{}) {
+	if e.actorSummary.Actor.IsSlotBlocked(e.GetSelectedSlot()) {
+		return
+	}
 	e.inInventoryList = true
 	e.SlotMenu.HideCursor()
 	//e.menuIndex = e.SlotMenu.GetIndex()
@@ -257,7 +310,7 @@ func (e *EquipMenuState) OnEquipMenuChanged() {
 //GetSelectedSlot takes index e.g. 3, returns "Accessory2"
 func (e EquipMenuState) GetSelectedSlot() string {
 	i := e.SlotMenu.GetIndex()
-	return combat.ActorLabels.EquipSlotId[i]
+	return e.actorSummary.Actor.EquipSlots[i].Id
 }
 
 func (e *EquipMenuState) GetSelectedItem() int {
@@ -0,0 +1,159 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/state_machine"
+	"github.com/steelx/go-rpg-cgm/utilz"
+	"golang.org/x/image/font/basicfont"
+)
+
+//FormationMenuState reorders the party, choose two members to swap them.
+//The first Party.MaxActive members go into battle, R changes row
+type FormationMenuState struct {
+	parent       *InGameMenuState
+	win          *pixelgl.Window
+	Layout       gui.Layout
+	StateMachine *state_machine.StateMachine
+	Panels       []gui.Panel
+	MembersMenu  *gui.SelectionMenu
+	Chosen       *combat.Actor //first member picked, nil if none
+	Notice       string
+}
+
+func FormationMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *FormationMenuState {
+	layout := gui.LayoutCreate(0, 0, win)
+	layout.Contract("screen", 118, 40)
+	layout.SplitHorz("screen", "title", "bottom", 0.12, 2)
+	layout.SplitHorz("bottom", "desc", "members", 0.14, 2)
+
+	return &FormationMenuState{
+		win:          win,
+		parent:       parent,
+		StateMachine: parent.StateMachine,
+		Layout:       layout,
+		Panels: []gui.Panel{
+			layout.CreatePanel("title"),
+			layout.CreatePanel("desc"),
+			layout.CreatePanel("members"),
+		},
+	}
+}
+
+func (f FormationMenuState) IsFinished() bool {
+	return true
+}
+
+func (f *FormationMenuState) Enter(data ...interface{}) {
+	f.Chosen = nil
+	f.Notice = ""
+	membersMenu := gui.SelectionMenuCreate(26, 0, 300,
+		f.parent.World.Party.ToArray(),
+		false,
+		pixel.V(0, 0),
+		f.OnMemberSelect,
+		f.RenderMember,
+	)
+	f.MembersMenu = &membersMenu
+}
+
+func (f *FormationMenuState) OnMemberSelect(index int, actorI interface{}) {
+	actor := reflect.ValueOf(actorI).Interface().(*combat.Actor)
+	if f.Chosen == nil {
+		f.Chosen = actor
+		f.Notice = fmt.Sprintf("Swap %s with?", actor.Name)
+		return
+	}
+
+	party := f.parent.World.Party
+	chosenIndex := party.FormationIndex(f.Chosen.Id)
+	if party.Swap(f.Chosen.Id, actor.Id) {
+		f.MembersMenu.DataI[index], f.MembersMenu.DataI[chosenIndex] = f.MembersMenu.DataI[chosenIndex], f.MembersMenu.DataI[index]
+		f.Notice = ""
+	} else if f.Chosen != actor {
+		f.Notice = "Someone able to fight must stay active."
+	}
+	f.Chosen = nil
+}
+
+func (f FormationMenuState) RenderMember(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, actor *combat.Actor
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	actor := reflect.ValueOf(a[3]).Interface().(*combat.Actor)
+
+	party := f.parent.World.Party
+	place := "Reserve"
+	color_ := utilz.HexToColor("#bbbbbb")
+	if party.IsActive(actor.Id) {
+		place = "Active"
+		color_ = utilz.HexToColor("#ffffff")
+	}
+	if actor == f.Chosen {
+		color_ = utilz.HexToColor("#ffff00")
+	}
+
+	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+	textBase.Color = color_
+	fmt.Fprintf(textBase, "%d. %-10s Lv %-3d HP %v/%v  %-7s %s Row",
+		party.FormationIndex(actor.Id)+1, actor.Name, actor.Level,
+		actor.Stats.Get("HpNow"), actor.Stats.Get("HpMax"), place, actor.Row)
+	textBase.Draw(renderer, pixel.IM)
+}
+
+func (f FormationMenuState) Render(win *pixelgl.Window) {
+	for _, v := range f.Panels {
+		v.Draw(win)
+	}
+
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+	titleX := f.Layout.Left("title") + 16
+	titleY := f.Layout.MidY("title")
+	textBase := text.New(pixel.V(titleX, titleY), basicAtlas)
+	fmt.Fprintf(textBase, "%s - Active: %v", frontMenuOrder[formation], f.parent.World.Party.MaxActive)
+	textBase.Draw(win, pixel.IM)
+
+	descX := f.Layout.Left("desc") + 20
+	descY := f.Layout.MidY("desc")
+	textBase = text.New(pixel.V(descX, descY), basicAtlas)
+	if f.Notice != "" {
+		fmt.Fprintln(textBase, f.Notice)
+	} else {
+		fmt.Fprintln(textBase, "Choose two members to swap their places, (R) Row.")
+	}
+	textBase.Draw(win, pixel.IM)
+
+	membersX := f.Layout.Left("members") - 6
+	membersY := f.Layout.Top("members") - 24
+	f.MembersMenu.SetPosition(membersX, membersY)
+	f.MembersMenu.Render(win)
+}
+
+func (f FormationMenuState) Exit() {
+
+}
+
+func (f *FormationMenuState) Update(dt float64) {
+	if f.win.JustReleased(pixelgl.KeyBackspace) || f.win.JustReleased(pixelgl.KeyEscape) {
+		if f.Chosen != nil {
+			f.Chosen = nil
+			f.Notice = ""
+			return
+		}
+		f.StateMachine.Change("frontmenu", nil)
+		return
+	}
+	if f.win.JustPressed(pixelgl.KeyR) {
+		actor := reflect.ValueOf(f.MembersMenu.SelectedItem()).Interface().(*combat.Actor)
+		actor.Row = actor.Row.Toggle()
+	}
+	f.MembersMenu.HandleInput(f.win)
+}
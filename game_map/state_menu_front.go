@@ -97,12 +97,12 @@ func (fm *FrontMenuState) OnPartyMemberChosen(actorIndex int, actorSummaryI inte
 }
 
 func (fm *FrontMenuState) OnMenuClick(index int, str interface{}) {
-	if index == items {
-		fm.StateMachine.Change(frontMenuOrder[items], nil)
+	if index == items || index == formation || index == config {
+		fm.StateMachine.Change(frontMenuOrder[index], nil)
 		return
 	}
 
-	if index == status || index == equip {
+	if index == status || index == magic || index == equip || index == job || index == skills {
 		fm.InPartyMenu = true
 		fm.Selections.HideCursor()
 		fm.PartyMenu.ShowCursor()
@@ -114,7 +114,7 @@ func (fm *FrontMenuState) OnMenuClick(index int, str interface{}) {
 }
 
 func (fm FrontMenuState) CreatePartySummaries() []combat.ActorSummary {
-	partyMembers := fm.Parent.World.Party.Members
+	partyMembers := fm.Parent.World.Party.ToArray()
 	var summaryList []combat.ActorSummary
 	for _, actor := range partyMembers {
 		summaryList = append(summaryList, combat.ActorSummaryCreate(*actor, true))
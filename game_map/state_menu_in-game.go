@@ -14,13 +14,23 @@ import (
 const (
 	status int = iota
 	items
+	magic
 	equip
+	job
+	skills
+	formation
+	config
 )
 
 var frontMenuOrder = []string{
 	"Status",
 	"Items",
+	"Magic",
 	"Equipment",
+	"Job",
+	"Skills",
+	"Formation",
+	"Config",
 }
 
 //parent
@@ -44,13 +54,24 @@ func InGameMenuStateCreate(stack *gui.StateStack, win *pixelgl.Window) *InGameMe
 		frontMenuOrder[items]: func() state_machine.State {
 			return ItemsMenuStateCreate(igm, win)
 		},
-		"magic": func() state_machine.State {
-			//return MagicMenuStateCreate(this)
-			return state_machine.Create(map[string]func() state_machine.State{})
+		frontMenuOrder[magic]: func() state_machine.State {
+			return MagicMenuStateCreate(igm, win)
 		},
 		frontMenuOrder[equip]: func() state_machine.State {
 			return EquipMenuStateCreate(igm, win)
 		},
+		frontMenuOrder[job]: func() state_machine.State {
+			return JobMenuStateCreate(igm, win)
+		},
+		frontMenuOrder[skills]: func() state_machine.State {
+			return SkillMenuStateCreate(igm, win)
+		},
+		frontMenuOrder[formation]: func() state_machine.State {
+			return FormationMenuStateCreate(igm, win)
+		},
+		frontMenuOrder[config]: func() state_machine.State {
+			return ConfigMenuStateCreate(igm, win)
+		},
 		frontMenuOrder[status]: func() state_machine.State {
 			//return StatusMenuStateCreate(this)
 			return StatusMenuStateCreate(igm, win)
@@ -7,12 +7,28 @@ import (
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
 	"github.com/steelx/go-rpg-cgm/gui"
 	"github.com/steelx/go-rpg-cgm/state_machine"
 	"github.com/steelx/go-rpg-cgm/world"
 	"golang.org/x/image/font/basicfont"
 )
 
+//ItemsMenuState category menu
+const (
+	itemsUse int = iota
+	itemsKey
+	itemsStorage
+	itemsSort
+)
+
+var itemCategories = []string{
+	"Use",
+	"Key Items",
+	"Storage",
+	"Sort",
+}
+
 type ItemsMenuState struct {
 	win            *pixelgl.Window
 	parent         *InGameMenuState
@@ -23,6 +39,8 @@ type ItemsMenuState struct {
 	ItemMenus      []*gui.SelectionMenu
 	CategoryMenu   *gui.SelectionMenu
 	InCategoryMenu bool
+	TargetMenu     *PartyTargetMenu //non nil while choosing who uses the item
+	UsingItem      world.Item
 }
 
 func ItemsMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *ItemsMenuState {
@@ -49,48 +67,99 @@ func ItemsMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *ItemsMe
 		layout.CreatePanel("inv"),
 	}
 
-	itemsMenu := gui.SelectionMenuCreate(24, 200, 120,
-		parent.World.Items,
+	im.RefreshItemMenus()
+
+	categoryMenu := gui.SelectionMenuCreate(24, 128, 0,
+		itemCategories,
 		true,
 		pixel.V(0, 0),
 		func(index int, s interface{}) {
-			//Items menu screen selection
+			im.OnCategorySelect(index, s)
 		},
-		parent.World.DrawItem,
+		nil,
+	)
+	im.CategoryMenu = &categoryMenu
+
+	return im
+}
+
+//RefreshItemMenus rebuilds the lists from World, e.g. after sorting
+func (im *ItemsMenuState) RefreshItemMenus() {
+	gWorld := im.parent.World
+
+	itemsMenu := gui.SelectionMenuCreate(24, 200, 120,
+		gWorld.Items,
+		true,
+		pixel.V(0, 0),
+		im.OnUseItem,
+		gWorld.DrawItem,
 	)
 	itemsMenu.Columns = 3
 	keyItemsMenu := gui.SelectionMenuCreate(24, 128, 100,
-		parent.World.KeyItems,
+		gWorld.KeyItems,
 		true,
 		pixel.V(0, 0),
 		func(index int, s interface{}) {
 			//Items menu screen selection
 		},
-		parent.World.DrawItem,
+		gWorld.DrawItem,
 	)
 	keyItemsMenu.Columns = 3
-	im.ItemMenus = []*gui.SelectionMenu{&itemsMenu, &keyItemsMenu}
-
-	categoryMenu := gui.SelectionMenuCreate(24, 128, 0,
-		[]string{"Use", "Key Items"},
+	storageMenu := gui.SelectionMenuCreate(24, 200, 120,
+		gWorld.Storage,
 		true,
 		pixel.V(0, 0),
-		func(index int, s interface{}) {
-			im.OnCategorySelect(index, s)
-		},
-		nil,
+		im.OnWithdrawItem,
+		gWorld.DrawItem,
 	)
-	im.CategoryMenu = &categoryMenu
+	storageMenu.Columns = 3
+	im.ItemMenus = []*gui.SelectionMenu{&itemsMenu, &keyItemsMenu, &storageMenu}
 
 	//initially since we are InCategoryMenu, we hide ItemMenus selection arrow
 	for _, v := range im.ItemMenus {
 		v.HideCursor()
 	}
+}
 
-	return im
+//OnWithdrawItem moves selected Storage item back to the inventory
+func (im *ItemsMenuState) OnWithdrawItem(index int, itemIdxI interface{}) {
+	itemIdx := reflect.ValueOf(itemIdxI).Interface().(world.ItemIndex)
+	im.parent.World.WithdrawItem(itemIdx.Id, itemIdx.Count)
+	im.RefreshItemMenus()
+	im.FocusOnCategoryMenu()
+}
+
+//OnUseItem opens PartyTargetMenu for Usable items which work outside of combat
+func (im *ItemsMenuState) OnUseItem(index int, itemIdxI interface{}) {
+	itemIdx := reflect.ValueOf(itemIdxI).Interface().(world.ItemIndex)
+	item := world.ItemsDB[itemIdx.Id]
+	if item.ItemType != world.Usable || !combat.CanUseInField(item.Use.Action) {
+		return
+	}
+
+	im.UsingItem = item
+	im.TargetMenu = PartyTargetMenuCreate(im.parent.World.Party.ToArray(), item.Use.Target, im.OnUseItemTargets)
+}
+
+//OnUseItemTargets applies the item, it's only used up if it had an effect
+func (im *ItemsMenuState) OnUseItemTargets(targets []*combat.Actor) {
+	item := im.UsingItem
+	if combat.ApplyFieldAction(item.Use.Action, item, targets) {
+		im.parent.World.RemoveItem(item.Id, 1)
+	}
+
+	im.TargetMenu = nil
+	im.RefreshItemMenus()
+	im.FocusOnCategoryMenu()
 }
 
 func (im *ItemsMenuState) OnCategorySelect(index int, value interface{}) {
+	if index == itemsSort {
+		im.parent.World.NextSortMode()
+		im.RefreshItemMenus()
+		return
+	}
+
 	im.CategoryMenu.HideCursor()
 	im.InCategoryMenu = false
 	menu := im.ItemMenus[index]
@@ -118,7 +187,7 @@ func (im ItemsMenuState) Render(win *pixelgl.Window) {
 
 	pos := pixel.V(titleX, titleY)
 	textBase := text.New(pos, text.NewAtlas(basicfont.Face7x13, text.ASCII))
-	fmt.Fprintln(textBase, "Items")
+	fmt.Fprintf(textBase, "Items (sort: %s)\n", world.SortModeLabels[im.parent.World.SortMode])
 	textBase.Draw(win, pixel.IM)
 
 	categoryX := im.Layout.Left("category") + 5
@@ -126,11 +195,22 @@ func (im ItemsMenuState) Render(win *pixelgl.Window) {
 	im.CategoryMenu.SetPosition(categoryX, categoryY)
 	im.CategoryMenu.Render(win)
 
-	menu := im.ItemMenus[im.CategoryMenu.GetIndex()]
+	menu := im.GetSelectedMenu()
 	if menu.IsDataSourceEmpty() {
 		return
 	}
 
+	if im.TargetMenu != nil {
+		descX := im.Layout.Left("mid") + 20
+		descY := im.Layout.MidY("mid")
+		textBase = text.New(pixel.V(descX, descY), gui.BasicAtlasAscii)
+		fmt.Fprintf(textBase, "%s: %s\n", im.UsingItem.Name, im.UsingItem.Use.Hint)
+		textBase.Draw(win, pixel.IM)
+
+		im.TargetMenu.Render(win, im.Layout.Left("inv")+20, im.Layout.Top("inv")-60)
+		return
+	}
+
 	if !im.InCategoryMenu || !im.CategoryMenu.IsShowCursor {
 		//convert interface to world.ItemIndex type
 		selectedItemIdx := reflect.ValueOf(menu.SelectedItem()).Interface().(world.ItemIndex)
@@ -165,7 +245,19 @@ func (im *ItemsMenuState) Update(dt float64) {
 		im.CategoryMenu.HandleInput(im.win)
 		return
 	}
-	menu := im.ItemMenus[im.CategoryMenu.GetIndex()]
+	if im.TargetMenu != nil {
+		im.TargetMenu.HandleInput(im.win)
+		if im.TargetMenu != nil && (im.win.JustReleased(pixelgl.KeyBackspace) || im.win.JustReleased(pixelgl.KeyEscape)) {
+			im.TargetMenu = nil
+		}
+		return
+	}
+
+	menu := im.GetSelectedMenu()
+	if menu.IsDataSourceEmpty() {
+		im.FocusOnCategoryMenu()
+		return
+	}
 	menu.HandleInput(im.win)
 	if im.win.JustReleased(pixelgl.KeyBackspace) || im.win.JustReleased(pixelgl.KeyEscape) {
 		im.FocusOnCategoryMenu()
@@ -174,7 +266,16 @@ func (im *ItemsMenuState) Update(dt float64) {
 
 func (im *ItemsMenuState) FocusOnCategoryMenu() {
 	im.InCategoryMenu = true
-	menu := im.ItemMenus[im.CategoryMenu.GetIndex()]
+	menu := im.GetSelectedMenu()
 	menu.HideCursor()
 	im.CategoryMenu.ShowCursor()
 }
+
+//GetSelectedMenu "Sort" has no list of its own, it shows the inventory
+func (im ItemsMenuState) GetSelectedMenu() *gui.SelectionMenu {
+	index := im.CategoryMenu.GetIndex()
+	if index >= len(im.ItemMenus) {
+		return im.ItemMenus[itemsUse]
+	}
+	return im.ItemMenus[index]
+}
@@ -0,0 +1,276 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/state_machine"
+	"github.com/steelx/go-rpg-cgm/utilz"
+	"golang.org/x/image/font/basicfont"
+)
+
+//JobMenuState changes an Actor's Job and fills ability slots
+//with abilities learned in other jobs
+type JobMenuState struct {
+	parent       *InGameMenuState
+	win          *pixelgl.Window
+	Layout       gui.Layout
+	StateMachine *state_machine.StateMachine
+	Panels       []gui.Panel
+	ActorSummary combat.ActorSummary
+	Actor        *combat.Actor //party member, ActorSummary holds a copy
+	JobsMenu     *gui.SelectionMenu
+	SlotsMenu    *gui.SelectionMenu
+	AbilityMenu  *gui.SelectionMenu //non nil while choosing what goes in a slot
+	inSlots      bool
+}
+
+func JobMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *JobMenuState {
+	layout := gui.LayoutCreate(0, 0, win)
+	layout.Contract("screen", 118, 40)
+	layout.SplitHorz("screen", "title", "bottom", 0.12, 2)
+	layout.SplitHorz("bottom", "desc", "bottom", 0.14, 2)
+	layout.SplitVert("bottom", "left", "party", 0.6, 2)
+	layout.SplitHorz("left", "jobs", "slots", 0.5, 2)
+
+	return &JobMenuState{
+		win:          win,
+		parent:       parent,
+		StateMachine: parent.StateMachine,
+		Layout:       layout,
+		Panels: []gui.Panel{
+			layout.CreatePanel("title"),
+			layout.CreatePanel("desc"),
+			layout.CreatePanel("jobs"),
+			layout.CreatePanel("slots"),
+			layout.CreatePanel("party"),
+		},
+	}
+}
+
+func (j JobMenuState) IsFinished() bool {
+	return true
+}
+
+func (j *JobMenuState) Enter(data ...interface{}) {
+	actorSummary := reflect.ValueOf(data[0]).Interface().(combat.ActorSummary)
+	j.Actor = j.parent.World.Party.Members[actorSummary.Actor.Id]
+	j.refreshSummary()
+	j.AbilityMenu = nil
+	j.inSlots = false
+
+	jobsMenu := gui.SelectionMenuCreate(26, 0, 200,
+		combat.JobOrder,
+		false,
+		pixel.V(0, 0),
+		j.OnJobSelect,
+		j.RenderJob,
+	)
+	j.JobsMenu = &jobsMenu
+
+	slots := make([]int, combat.MaxAbilitySlots)
+	for i := range slots {
+		slots[i] = i
+	}
+	slotsMenu := gui.SelectionMenuCreate(26, 0, 200,
+		slots,
+		false,
+		pixel.V(0, 0),
+		j.OnSlotSelect,
+		j.RenderSlot,
+	)
+	j.SlotsMenu = &slotsMenu
+	j.SlotsMenu.HideCursor()
+}
+
+func (j *JobMenuState) refreshSummary() {
+	j.ActorSummary = combat.ActorSummaryCreate(*j.Actor, true)
+	j.ActorSummary.HideXP()
+}
+
+func (j *JobMenuState) OnJobSelect(index int, jobI interface{}) {
+	if j.Actor.ChangeJob(reflect.ValueOf(jobI).Interface().(string)) {
+		j.refreshSummary()
+	}
+}
+
+//OnSlotSelect lists abilities learned in other jobs, first entry empties the slot
+func (j *JobMenuState) OnSlotSelect(index int, slotI interface{}) {
+	abilities := append([]combat.Ability{{}}, j.Actor.SlottableAbilities()...)
+	abilityMenu := gui.SelectionMenuCreate(26, 0, 200,
+		abilities,
+		false,
+		pixel.V(0, 0),
+		j.OnAbilitySelect,
+		j.RenderAbility,
+	)
+	j.AbilityMenu = &abilityMenu
+	j.SlotsMenu.HideCursor()
+}
+
+func (j *JobMenuState) OnAbilitySelect(index int, abilityI interface{}) {
+	ab := reflect.ValueOf(abilityI).Interface().(combat.Ability)
+	if j.Actor.SetAbilitySlot(j.SlotsMenu.GetIndex(), ab) {
+		j.refreshSummary()
+		j.closeAbilityMenu()
+	}
+}
+
+func (j *JobMenuState) closeAbilityMenu() {
+	j.AbilityMenu = nil
+	j.SlotsMenu.ShowCursor()
+}
+
+func (j *JobMenuState) focusSlots(inSlots bool) {
+	j.inSlots = inSlots
+	if inSlots {
+		j.JobsMenu.HideCursor()
+		j.SlotsMenu.ShowCursor()
+		return
+	}
+	j.SlotsMenu.HideCursor()
+	j.JobsMenu.ShowCursor()
+}
+
+func (j JobMenuState) RenderJob(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, jobId string
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	jobId := reflect.ValueOf(a[3]).Interface().(string)
+
+	color_ := utilz.HexToColor("#ffffff")
+	if jobId == j.Actor.Job {
+		color_ = utilz.HexToColor("#ffff00")
+	}
+
+	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+	textBase.Color = color_
+	fmt.Fprintf(textBase, "%s Lv %v", combat.JobsDB[jobId].Name, j.Actor.JobLevel(jobId))
+	textBase.Draw(renderer, pixel.IM)
+}
+
+func (j JobMenuState) RenderSlot(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, slot int
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	slot := reflect.ValueOf(a[3]).Interface().(int)
+
+	var ab combat.Ability
+	if slot < len(j.Actor.AbilitySlots) {
+		ab = j.Actor.AbilitySlots[slot]
+	}
+	j.RenderAbility(renderer, x, y, ab)
+}
+
+func (j JobMenuState) RenderAbility(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, ability combat.Ability
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	ab := reflect.ValueOf(a[3]).Interface().(combat.Ability)
+
+	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+	if ab.Id == "" {
+		fmt.Fprint(textBase, "--")
+	} else {
+		fmt.Fprintf(textBase, "%s (%s)", ab.Name(), ab.Action)
+	}
+	textBase.Draw(renderer, pixel.IM)
+}
+
+func (j JobMenuState) Render(win *pixelgl.Window) {
+	for _, v := range j.Panels {
+		v.Draw(win)
+	}
+
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+	titleX := j.Layout.Left("title") + 16
+	titleY := j.Layout.MidY("title")
+	textBase := text.New(pixel.V(titleX, titleY), basicAtlas)
+	fmt.Fprintln(textBase, frontMenuOrder[job])
+	textBase.Draw(win, pixel.IM)
+
+	descX := j.Layout.Left("desc") + 20
+	descY := j.Layout.MidY("desc")
+	textBase = text.New(pixel.V(descX, descY), basicAtlas)
+	if j.AbilityMenu != nil {
+		fmt.Fprintln(textBase, "Choose an ability learned in another job.")
+	} else if j.inSlots {
+		fmt.Fprintln(textBase, "Ability slots. (Left) jobs")
+	} else {
+		jobId := reflect.ValueOf(j.JobsMenu.SelectedItem()).Interface().(string)
+		fmt.Fprintf(textBase, "Job XP %v/%v. (Right) ability slots\n", j.Actor.JobXP[jobId], j.nextJobLevelXP(jobId))
+	}
+	textBase.Draw(win, pixel.IM)
+
+	jobsX := j.Layout.Left("jobs") - 6
+	jobsY := j.Layout.Top("jobs") - 24
+	j.JobsMenu.SetPosition(jobsX, jobsY)
+	j.JobsMenu.Render(win)
+
+	slotsX := j.Layout.Left("slots") - 6
+	slotsY := j.Layout.Top("slots") - 24
+	if j.AbilityMenu != nil {
+		j.AbilityMenu.SetPosition(slotsX, slotsY)
+		j.AbilityMenu.Render(win)
+	} else {
+		j.SlotsMenu.SetPosition(slotsX, slotsY)
+		j.SlotsMenu.Render(win)
+	}
+
+	partyX := j.Layout.Left("party") + 10
+	partyY := j.Layout.Top("party") - 60
+	j.ActorSummary.SetPosition(partyX, partyY+35)
+	j.ActorSummary.Render(win)
+}
+
+//nextJobLevelXP shows max level XP once the job is mastered
+func (j JobMenuState) nextJobLevelXP(jobId string) float64 {
+	level := j.Actor.JobLevel(jobId)
+	if level >= len(combat.JobLevelXP) {
+		return combat.JobLevelXP[len(combat.JobLevelXP)-1]
+	}
+	return combat.JobLevelXP[level]
+}
+
+func (j JobMenuState) Exit() {
+
+}
+
+func (j *JobMenuState) Update(dt float64) {
+	escape := j.win.JustReleased(pixelgl.KeyBackspace) || j.win.JustReleased(pixelgl.KeyEscape)
+
+	if j.AbilityMenu != nil {
+		j.AbilityMenu.HandleInput(j.win)
+		if j.AbilityMenu != nil && escape {
+			j.closeAbilityMenu()
+		}
+		return
+	}
+
+	if escape {
+		j.StateMachine.Change("frontmenu", nil)
+		return
+	}
+
+	if j.inSlots {
+		j.SlotsMenu.HandleInput(j.win)
+		if j.win.JustPressed(pixelgl.KeyLeft) {
+			j.focusSlots(false)
+		}
+		return
+	}
+
+	j.JobsMenu.HandleInput(j.win)
+	if j.win.JustPressed(pixelgl.KeyRight) {
+		j.focusSlots(true)
+	}
+}
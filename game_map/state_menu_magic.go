@@ -0,0 +1,199 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/state_machine"
+	"github.com/steelx/go-rpg-cgm/utilz"
+	"github.com/steelx/go-rpg-cgm/world"
+	"golang.org/x/image/font/basicfont"
+)
+
+//MagicMenuState casts field magic e.g. Heal, outside of combat
+type MagicMenuState struct {
+	parent       *InGameMenuState
+	win          *pixelgl.Window
+	Layout       gui.Layout
+	StateMachine *state_machine.StateMachine
+	Panels       []gui.Panel
+	ActorSummary combat.ActorSummary
+	Caster       *combat.Actor
+	SpellsMenu   *gui.SelectionMenu
+	TargetMenu   *PartyTargetMenu //non nil while choosing who the spell is cast on
+	CastingSpell world.SpecialItem
+}
+
+func MagicMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *MagicMenuState {
+	layout := gui.LayoutCreate(0, 0, win)
+	layout.Contract("screen", 118, 40)
+	layout.SplitHorz("screen", "title", "bottom", 0.12, 2)
+	layout.SplitHorz("bottom", "desc", "bottom", 0.14, 2)
+	layout.SplitVert("bottom", "spells", "party", 0.6, 2)
+
+	return &MagicMenuState{
+		win:          win,
+		parent:       parent,
+		StateMachine: parent.StateMachine,
+		Layout:       layout,
+		Panels: []gui.Panel{
+			layout.CreatePanel("title"),
+			layout.CreatePanel("desc"),
+			layout.CreatePanel("spells"),
+			layout.CreatePanel("party"),
+		},
+	}
+}
+
+func (m MagicMenuState) IsFinished() bool {
+	return true
+}
+
+func (m *MagicMenuState) Enter(data ...interface{}) {
+	m.ActorSummary = reflect.ValueOf(data[0]).Interface().(combat.ActorSummary)
+	m.ActorSummary.HideXP()
+	m.Caster = m.parent.World.Party.Members[m.ActorSummary.Actor.Id]
+	m.TargetMenu = nil
+
+	var spells []string
+	if m.Caster.HasAction(combat.ActionMagic) {
+		spells = m.Caster.Magic
+	}
+
+	spellsMenu := gui.SelectionMenuCreate(26, 0, 200,
+		spells,
+		false,
+		pixel.V(0, 0),
+		m.OnSpellSelect,
+		m.RenderSpell,
+	)
+	m.SpellsMenu = &spellsMenu
+}
+
+//CanCast only restorative spells can be cast outside of combat
+func (m MagicMenuState) CanCast(def world.SpecialItem) bool {
+	return combat.CanUseInField(def.Action) && m.Caster.Stats.Get("MpNow") >= m.Caster.MpCost(def.MpCost)
+}
+
+func (m *MagicMenuState) OnSpellSelect(index int, spellI interface{}) {
+	def := world.SpellsDB[reflect.ValueOf(spellI).Interface().(string)]
+	if !m.CanCast(def) {
+		return
+	}
+
+	m.CastingSpell = def
+	m.SpellsMenu.HideCursor()
+	m.TargetMenu = PartyTargetMenuCreate(m.parent.World.Party.ToArray(), def.Target, m.OnSpellTargets)
+}
+
+//OnSpellTargets MP is only spent if the spell had an effect
+func (m *MagicMenuState) OnSpellTargets(targets []*combat.Actor) {
+	def := m.CastingSpell
+	if combat.ApplyFieldAction(def.Action, def, targets) {
+		mpNow := m.Caster.Stats.Get("MpNow")
+		m.Caster.Stats.Set("MpNow", mpNow-m.Caster.MpCost(def.MpCost))
+	}
+	m.closeTargetMenu()
+}
+
+func (m *MagicMenuState) closeTargetMenu() {
+	m.TargetMenu = nil
+	m.SpellsMenu.ShowCursor()
+}
+
+func (m MagicMenuState) RenderSpell(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, spell string
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	spell := reflect.ValueOf(a[3]).Interface().(string)
+
+	def, ok := world.SpellsDB[spell]
+	if !ok {
+		panic(fmt.Sprintf("Key '%s' not found in SpellsDB", spell))
+	}
+
+	color_ := utilz.HexToColor("#bbbbbb")
+	if m.CanCast(def) {
+		color_ = utilz.HexToColor("#ffffff")
+	}
+
+	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+	textBase.Color = color_
+	fmt.Fprintf(textBase, "%s (%v)", def.Name, m.Caster.MpCost(def.MpCost))
+	textBase.Draw(renderer, pixel.IM)
+}
+
+func (m MagicMenuState) Render(win *pixelgl.Window) {
+	for _, v := range m.Panels {
+		v.Draw(win)
+	}
+
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+	titleX := m.Layout.Left("title") + 16
+	titleY := m.Layout.MidY("title")
+	textBase := text.New(pixel.V(titleX, titleY), basicAtlas)
+	fmt.Fprintln(textBase, frontMenuOrder[magic])
+	textBase.Draw(win, pixel.IM)
+
+	descX := m.Layout.Left("desc") + 20
+	descY := m.Layout.MidY("desc")
+	textBase = text.New(pixel.V(descX, descY), basicAtlas)
+	if m.SpellsMenu.IsDataSourceEmpty() {
+		fmt.Fprintf(textBase, "%s has not learned any magic.\n", m.Caster.Name)
+	} else if m.TargetMenu != nil {
+		fmt.Fprintf(textBase, "%s: choose a target.\n", m.CastingSpell.Name)
+	} else {
+		def := world.SpellsDB[reflect.ValueOf(m.SpellsMenu.SelectedItem()).Interface().(string)]
+		if combat.CanUseInField(def.Action) {
+			fmt.Fprintf(textBase, "MP %v/%v\n", m.Caster.Stats.Get("MpNow"), m.Caster.Stats.Get("MpMax"))
+		} else {
+			fmt.Fprintln(textBase, "Can only be cast in combat.")
+		}
+	}
+	textBase.Draw(win, pixel.IM)
+
+	spellsX := m.Layout.Left("spells") - 6
+	spellsY := m.Layout.Top("spells") - 24
+	m.SpellsMenu.SetPosition(spellsX, spellsY)
+	m.SpellsMenu.Render(win)
+
+	partyX := m.Layout.Left("party") + 10
+	partyY := m.Layout.Top("party") - 60
+	if m.TargetMenu != nil {
+		m.TargetMenu.Render(win, partyX, partyY)
+		return
+	}
+	m.ActorSummary.SetPosition(partyX, partyY+35)
+	m.ActorSummary.Render(win)
+}
+
+func (m MagicMenuState) Exit() {
+
+}
+
+func (m *MagicMenuState) Update(dt float64) {
+	escape := m.win.JustReleased(pixelgl.KeyBackspace) || m.win.JustReleased(pixelgl.KeyEscape)
+
+	if m.TargetMenu != nil {
+		m.TargetMenu.HandleInput(m.win)
+		if m.TargetMenu != nil && escape {
+			m.closeTargetMenu()
+		}
+		return
+	}
+
+	if escape {
+		m.StateMachine.Change("frontmenu", nil)
+		return
+	}
+	if !m.SpellsMenu.IsDataSourceEmpty() {
+		m.SpellsMenu.HandleInput(m.win)
+	}
+}
@@ -0,0 +1,168 @@
+package game_map
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/faiface/pixel/text"
+	"github.com/steelx/go-rpg-cgm/combat"
+	"github.com/steelx/go-rpg-cgm/gui"
+	"github.com/steelx/go-rpg-cgm/state_machine"
+	"github.com/steelx/go-rpg-cgm/utilz"
+	"golang.org/x/image/font/basicfont"
+)
+
+//SkillMenuState spends skill points in the Actor's SkillTree,
+//R respecs the tree for gold
+type SkillMenuState struct {
+	parent       *InGameMenuState
+	win          *pixelgl.Window
+	Layout       gui.Layout
+	StateMachine *state_machine.StateMachine
+	Panels       []gui.Panel
+	ActorSummary combat.ActorSummary
+	Actor        *combat.Actor //party member, ActorSummary holds a copy
+	NodesMenu    *gui.SelectionMenu
+}
+
+func SkillMenuStateCreate(parent *InGameMenuState, win *pixelgl.Window) *SkillMenuState {
+	layout := gui.LayoutCreate(0, 0, win)
+	layout.Contract("screen", 118, 40)
+	layout.SplitHorz("screen", "title", "bottom", 0.12, 2)
+	layout.SplitHorz("bottom", "desc", "bottom", 0.14, 2)
+	layout.SplitVert("bottom", "nodes", "party", 0.6, 2)
+
+	return &SkillMenuState{
+		win:          win,
+		parent:       parent,
+		StateMachine: parent.StateMachine,
+		Layout:       layout,
+		Panels: []gui.Panel{
+			layout.CreatePanel("title"),
+			layout.CreatePanel("desc"),
+			layout.CreatePanel("nodes"),
+			layout.CreatePanel("party"),
+		},
+	}
+}
+
+func (s SkillMenuState) IsFinished() bool {
+	return true
+}
+
+func (s *SkillMenuState) Enter(data ...interface{}) {
+	actorSummary := reflect.ValueOf(data[0]).Interface().(combat.ActorSummary)
+	s.Actor = s.parent.World.Party.Members[actorSummary.Actor.Id]
+	s.refreshSummary()
+
+	nodesMenu := gui.SelectionMenuCreate(26, 0, 200,
+		s.Actor.SkillTree().Nodes,
+		false,
+		pixel.V(0, 0),
+		s.OnNodeSelect,
+		s.RenderNode,
+	)
+	s.NodesMenu = &nodesMenu
+}
+
+func (s *SkillMenuState) refreshSummary() {
+	s.ActorSummary = combat.ActorSummaryCreate(*s.Actor, true)
+	s.ActorSummary.HideXP()
+}
+
+func (s *SkillMenuState) OnNodeSelect(index int, nodeI interface{}) {
+	node := reflect.ValueOf(nodeI).Interface().(combat.SkillNode)
+	if s.Actor.UnlockSkill(node.Id) {
+		s.refreshSummary()
+	}
+}
+
+func (s SkillMenuState) RenderNode(a ...interface{}) {
+	//renderer pixel.Target, x, y float64, node combat.SkillNode
+	renderer := reflect.ValueOf(a[0]).Interface().(pixel.Target)
+	x := reflect.ValueOf(a[1]).Interface().(float64)
+	y := reflect.ValueOf(a[2]).Interface().(float64)
+	node := reflect.ValueOf(a[3]).Interface().(combat.SkillNode)
+
+	color_ := utilz.HexToColor("#bbbbbb")
+	if s.Actor.HasSkill(node.Id) {
+		color_ = utilz.HexToColor("#ffff00")
+	} else if s.Actor.CanUnlockSkill(node.Id) {
+		color_ = utilz.HexToColor("#ffffff")
+	}
+
+	textBase := text.New(pixel.V(x, y), gui.BasicAtlasAscii)
+	textBase.Color = color_
+	fmt.Fprintf(textBase, "%s (%v)", node.Name, node.Cost)
+	textBase.Draw(renderer, pixel.IM)
+}
+
+func (s SkillMenuState) Render(win *pixelgl.Window) {
+	for _, v := range s.Panels {
+		v.Draw(win)
+	}
+
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+	titleX := s.Layout.Left("title") + 16
+	titleY := s.Layout.MidY("title")
+	textBase := text.New(pixel.V(titleX, titleY), basicAtlas)
+	fmt.Fprintf(textBase, "%s - Points: %v", frontMenuOrder[skills], s.Actor.SkillPoints)
+	textBase.Draw(win, pixel.IM)
+
+	descX := s.Layout.Left("desc") + 20
+	descY := s.Layout.MidY("desc")
+	textBase = text.New(pixel.V(descX, descY), basicAtlas)
+	if s.NodesMenu.IsDataSourceEmpty() {
+		fmt.Fprintf(textBase, "%s has no skill tree.\n", s.Actor.Name)
+	} else {
+		node := reflect.ValueOf(s.NodesMenu.SelectedItem()).Interface().(combat.SkillNode)
+		fmt.Fprint(textBase, node.Description)
+		if len(node.Requires) > 0 {
+			var names []string
+			for _, req := range node.Requires {
+				reqNode, _ := s.Actor.SkillTree().Node(req)
+				names = append(names, reqNode.Name)
+			}
+			fmt.Fprintf(textBase, ". Requires %s", strings.Join(names, ", "))
+		}
+		fmt.Fprintln(textBase)
+	}
+	textBase.Draw(win, pixel.IM)
+
+	nodesX := s.Layout.Left("nodes") - 6
+	nodesY := s.Layout.Top("nodes") - 24
+	s.NodesMenu.SetPosition(nodesX, nodesY)
+	s.NodesMenu.Render(win)
+
+	partyX := s.Layout.Left("party") + 10
+	partyY := s.Layout.Top("party") - 60
+	s.ActorSummary.SetPosition(partyX, partyY+35)
+	s.ActorSummary.Render(win)
+
+	textBase = text.New(pixel.V(partyX, partyY-80), basicAtlas)
+	if len(s.Actor.Skills) > 0 {
+		fmt.Fprintf(textBase, "(R) Respec: %v GP\n", combat.RespecCost(*s.Actor))
+	}
+	textBase.Draw(win, pixel.IM)
+}
+
+func (s SkillMenuState) Exit() {
+
+}
+
+func (s *SkillMenuState) Update(dt float64) {
+	if s.win.JustReleased(pixelgl.KeyBackspace) || s.win.JustReleased(pixelgl.KeyEscape) {
+		s.StateMachine.Change("frontmenu", nil)
+		return
+	}
+	if s.win.JustPressed(pixelgl.KeyR) && s.parent.World.Respec(s.Actor) {
+		s.refreshSummary()
+	}
+	if !s.NodesMenu.IsDataSourceEmpty() {
+		s.NodesMenu.HandleInput(s.win)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/steelx/go-rpg-cgm/combat"
 	"github.com/steelx/go-rpg-cgm/gui"
 	"github.com/steelx/go-rpg-cgm/state_machine"
+	"github.com/steelx/go-rpg-cgm/world"
 	"golang.org/x/image/font/basicfont"
 )
 
@@ -60,7 +61,7 @@ func (s *StatusMenuState) Enter(data ...interface{}) {
 
 	s.spacingY = 26
 	equipmentMenu := gui.SelectionMenuCreate(s.spacingY, 40, 100,
-		s.ActorSummary.Actor.ActiveEquipSlots,
+		s.ActorSummary.Actor.EquipSlots,
 		false,
 		pixel.V(0, 0),
 		func(i int, equipId interface{}) {
@@ -109,10 +110,20 @@ func (s StatusMenuState) Render(renderer *pixelgl.Window) {
 	s.ActorSummary.SetPosition(left, top)
 	s.ActorSummary.Render(renderer)
 
-	xp := fmt.Sprintf("XP: %v/%v", s.ActorSummary.Actor.XP, s.ActorSummary.Actor.NextLevelXP)
+	actor := s.ActorSummary.Actor
+	xp := fmt.Sprintf("XP: %v/%v", actor.XP, actor.NextLevelXP)
+	if actor.IsMaxLevel() {
+		xp = fmt.Sprintf("XP: %v (Max Level)", actor.XP)
+	}
 	pos = pixel.V(left+380, top-25)
 	textBase = text.New(pos, basicAtlasAscii)
 	fmt.Fprintln(textBase, xp)
+	for i, req := range actor.XPCurve.Requirements(actor.Level+1, 2) {
+		fmt.Fprintf(textBase, "Lv %d: %v XP\n", actor.Level+i+2, req)
+	}
+	if def, ok := combat.JobsDB[actor.Job]; ok {
+		fmt.Fprintf(textBase, "Job: %s Lv %v\n", def.Name, actor.JobLevel(actor.Job))
+	}
 	textBase.Draw(renderer, pixel.IM)
 
 	// Equipments - Bottom Right
@@ -124,6 +135,10 @@ func (s StatusMenuState) Render(renderer *pixelgl.Window) {
 	s.EquipMenu.SetPosition(equipMenuLeft, 0)
 	s.EquipMenu.Render(renderer)
 
+	// Abilities taught by equipment - below Equipments
+	slotsH := float64(len(s.ActorSummary.Actor.EquipSlots)) * s.spacingY
+	s.DrawAbilities(renderer, equipMenuLeft, -slotsH-s.spacingY)
+
 	// BaseStats - Bottom Left
 	x := left + 50
 	y := 0.0
@@ -148,6 +163,12 @@ func (s StatusMenuState) Render(renderer *pixelgl.Window) {
 		y -= spaceY
 	}
 
+	y -= spaceY
+	for _, id := range world.DerivedStatOrder {
+		s.DrawDerivedStat(renderer, x, y, world.DerivedStatsDB[id].Label, s.ActorSummary.Actor.StatBreakdown(id))
+		y -= spaceY
+	}
+
 	// this should be a panel
 	var x1, y1, w, h float64 = 75, 25, 100, 56
 	box := gui.TextboxCreateFixed(
@@ -181,6 +202,35 @@ func (s StatusMenuState) Update(dt float64) {
 // StatusMenuState additional methods below //
 //////////////////////////////////////////////
 
+//DrawAbilities AP progress of abilities taught by equipped items
+func (s StatusMenuState) DrawAbilities(renderer pixel.Target, x, y float64) {
+	teachables := s.ActorSummary.Actor.Teachables()
+	if len(teachables) == 0 {
+		return
+	}
+
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+	textBase := text.New(pixel.V(x-25, y), basicAtlas)
+	fmt.Fprintln(textBase, "Abilities ->")
+	for _, t := range teachables {
+		name := combat.AbilityName(t)
+		if s.ActorSummary.Actor.IsMastered(t) {
+			fmt.Fprintf(textBase, "  %-14s: mastered\n", name)
+			continue
+		}
+		fmt.Fprintf(textBase, "  %-14s: %v/%v AP\n", name, s.ActorSummary.Actor.AP[t.Id], t.AP)
+	}
+	textBase.Draw(renderer, pixel.IM)
+}
+
+//DrawDerivedStat as percent, followed by base, equipment and buff parts
+func (s StatusMenuState) DrawDerivedStat(renderer pixel.Target, x, y float64, label string, b world.StatBreakdown) {
+	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+	textBase := text.New(pixel.V(x, y), basicAtlas)
+	fmt.Fprintf(textBase, "%-14s: %.1f%% (%.1f %+.1f %+.1f)\n", label, b.Total*100, b.Base*100, b.Equipment*100, b.Buffs*100)
+	textBase.Draw(renderer, pixel.IM)
+}
+
 func (s StatusMenuState) DrawStat(renderer pixel.Target, x, y float64, label string, value float64) {
 	basicAtlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
 	pos := pixel.V(x, y)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
@@ -16,7 +17,8 @@ import (
 type LootSummaryState struct {
 	win        *pixelgl.Window
 	Stack      *gui.StateStack
-	CombatData CombatData
+	CombatData combat.CombatData
+	Rewards    combat.Rewards
 	World      *combat.WorldExtended
 	Layout     gui.Layout
 	Panels     []gui.Panel
@@ -29,7 +31,7 @@ type LootSummaryState struct {
 	OnWinCallback  func()
 }
 
-func LootSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, world *combat.WorldExtended, combatData CombatData, onWinCallback func()) *LootSummaryState {
+func LootSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, world *combat.WorldExtended, combatData combat.CombatData, rewards combat.Rewards, onWinCallback func()) *LootSummaryState {
 	layout := gui.LayoutCreate(0, 0, win)
 	layout.Contract("screen", 120, 40)
 	layout.SplitHorz("screen", "top", "bottom", 0.25, 2)
@@ -40,10 +42,11 @@ func LootSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, world *c
 		win:            win,
 		Stack:          stack,
 		CombatData:     combatData,
+		Rewards:        rewards,
 		World:          world,
 		Layout:         layout,
 		Loot:           combatData.Loot,
-		Gold:           combatData.Gold,
+		Gold:           rewards.Gold,
 		GoldPerSec:     5.0,
 		GoldCounter:    0,
 		IsCountingGold: true,
@@ -147,6 +150,9 @@ func (s *LootSummaryState) Render(renderer *pixelgl.Window) {
 	goldStr := fmt.Sprintf("Gold Found: %+6v Gold", s.Gold)
 	textBase = text.New(pixel.V(leftX, leftY), gui.BasicAtlas12)
 	fmt.Fprintln(textBase, goldStr)
+	if len(s.Rewards.GoldNotes) > 0 {
+		fmt.Fprintln(textBase, strings.Join(s.Rewards.GoldNotes, ", "))
+	}
 	textBase.Draw(renderer, pixel.IM)
 
 	rightX := s.Layout.Left("right") + 12
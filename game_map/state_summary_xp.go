@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
@@ -16,7 +17,9 @@ import (
 type XPSummaryState struct {
 	win        *pixelgl.Window
 	Stack      *gui.StateStack
-	CombatData CombatData
+	CombatData combat.CombatData
+	Rewards    combat.Rewards
+	XPGiven    map[string]float64 //actor id -> part of Rewards.XP given so far
 	Layout     gui.Layout
 	TitlePanels,
 	ActorPanels []gui.Panel
@@ -30,12 +33,9 @@ type XPSummaryState struct {
 	OnWinCallback func()
 }
 
-type CombatData struct {
-	XP, Gold float64
-	Loot     []world.ItemIndex
-}
-
-func XPSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, party combat.Party, combatData CombatData, onWinCallback func()) *XPSummaryState {
+//XPSummaryStateCreate XP is counted up for every member at once,
+//each one getting its share of party.World.RewardRules
+func XPSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, party combat.Party, combatData combat.CombatData, onWinCallback func()) *XPSummaryState {
 	layout := gui.LayoutCreate(0, 0, win)
 	layout.Contract("screen", 120, 40)
 	layout.SplitHorz("screen", "top", "bottom", 0.5, 2)
@@ -44,17 +44,21 @@ func XPSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, party comb
 
 	layout.SplitHorz("top", "title", "detail", 0.5, 2)
 
+	partyList := party.ToArray()
+	rewards := combat.CalcRewards(party.World.RewardRules, combatData, partyList)
 	s := &XPSummaryState{
 		win:           win,
 		Stack:         stack,
 		CombatData:    combatData,
+		Rewards:       rewards,
+		XPGiven:       make(map[string]float64),
 		Layout:        layout,
-		XP:            combatData.XP,
-		XPcopy:        combatData.XP,
+		XP:            rewards.MaxXP(),
+		XPcopy:        rewards.MaxXP(),
 		XPPerSec:      5.0,
 		XPCounter:     0,
 		IsCountingXP:  true,
-		Party:         party.ToArray(),
+		Party:         partyList,
 		OnWinCallback: onWinCallback,
 	}
 
@@ -84,6 +88,7 @@ func XPSummaryStateCreate(stack *gui.StateStack, win *pixelgl.Window, party comb
 		s.PartySummary = append(s.PartySummary, summary)
 		index++
 	}
+	s.ApplyAPToParty(combatData.AP)
 
 	return s
 }
@@ -107,15 +112,16 @@ func (s *XPSummaryState) Update(dt float64) bool {
 		s.XPCounter = s.XPCounter + s.XPPerSec*dt
 		xpToApply := math.Floor(s.XPCounter)
 		s.XPCounter = s.XPCounter - xpToApply
-		s.XP = s.XP - xpToApply
+		s.XP = math.Max(0, s.XP-xpToApply)
 
-		s.ApplyXPToParty(xpToApply)
+		s.ApplyXPToParty()
 
 		if s.XP == 0 {
 			s.IsCountingXP = false
 		}
 
 	}
+	s.LevelUpParty()
 
 	return false //we dont want to update other states
 }
@@ -140,8 +146,11 @@ func (s *XPSummaryState) Render(renderer *pixelgl.Window) {
 	detailY := s.Layout.MidY("detail")
 	pos = pixel.V(detailX, detailY)
 	textBase = text.New(pos, gui.BasicAtlasAscii)
-	detailStr := fmt.Sprintf("XP increased by %v.", s.XPcopy)
+	detailStr := fmt.Sprintf("XP increased by %v. AP increased by %v.", s.XPcopy, s.CombatData.AP)
 	fmt.Fprintln(textBase, detailStr)
+	if len(s.Rewards.XPNotes) > 0 {
+		fmt.Fprintln(textBase, strings.Join(s.Rewards.XPNotes, ", "))
+	}
 	textBase.Draw(renderer, pixel.IM)
 
 	for i := 0; i < len(s.PartySummary); i++ {
@@ -167,6 +176,10 @@ func (s *XPSummaryState) HandleInput(win *pixelgl.Window) {
 			return
 		}
 
+		if s.AreLevelUpsRemaining() {
+			return
+		}
+
 		s.GotoLootSummary()
 	}
 }
@@ -179,6 +192,12 @@ func (s *XPSummaryState) UnlockPopUps(summary *combat.ActorXPSummary, levelUpAct
 			db = world.SpellsDB
 			hexColor = "#b725ff"
 		}
+		if k == combat.ActionPassive {
+			for _, id := range v {
+				summary.AddPopUp(fmt.Sprintf("+ %s", world.PassivesDB[id].Name), "#25d5ff")
+			}
+			continue
+		}
 
 		for _, id := range v {
 			msg := fmt.Sprintf("+ %s", db[id].Name)
@@ -187,21 +206,71 @@ func (s *XPSummaryState) UnlockPopUps(summary *combat.ActorXPSummary, levelUpAct
 	}
 }
 
-func (s *XPSummaryState) ApplyXPToParty(xp float64) {
+//ApplyXPToParty gives every member the part of its reward counted so far
+func (s *XPSummaryState) ApplyXPToParty() {
+	progress := 1.0
+	if s.XPcopy > 0 {
+		progress = (s.XPcopy - s.XP) / s.XPcopy
+	}
+	for k, actor := range s.Party {
+		reward := s.Rewards.XP[actor.Id]
+		xp := math.Floor(reward*progress) - s.XPGiven[actor.Id]
+		if s.XP == 0 {
+			xp = reward - s.XPGiven[actor.Id]
+		}
+		if xp <= 0 {
+			continue
+		}
+		s.XPGiven[actor.Id] += xp
+
+		actor.AddXP(xp)
+		for _, ab := range actor.AddJobXP(xp) {
+			s.PartySummary[k].AddPopUp(fmt.Sprintf("+ %s", ab.Name()), "#25d5ff")
+		}
+	}
+}
+
+//LevelUpParty applies one LevelUp per actor at a time, the next one
+//waits until all but the last popup of the previous one are gone
+func (s *XPSummaryState) LevelUpParty() {
 	for k, actor := range s.Party {
-		if actor.Stats.Get("HpNow") > 0 {
-			summary := s.PartySummary[k]
-			actor.AddXP(xp)
+		summary := s.PartySummary[k]
+		if actor.IsKOed() || !actor.ReadyToLevelUp() || summary.PopUpCount() > 1 {
+			continue
+		}
 
-			for actor.ReadyToLevelUp() {
-				levelUp := actor.CreateLevelUp()
-				levelNumber := actor.Level + levelUp.Level
-				summary.AddPopUp(fmt.Sprintf("Level Up! %d", levelNumber), "#e9d79b")
+		levelUp := actor.CreateLevelUp()
+		levelNumber := actor.Level + levelUp.Level
+		summary.AddPopUp(fmt.Sprintf("Level Up! %d", levelNumber), "#e9d79b")
 
-				s.UnlockPopUps(summary, levelUp.Actions)
+		s.UnlockPopUps(summary, levelUp.Actions)
+		summary.AddPopUp(fmt.Sprintf("+%d Skill Point", levelUp.SkillPoints), "#ffd025")
 
-				actor.ApplyLevel(levelUp)
-			}
+		actor.ApplyLevel(levelUp)
+	}
+}
+
+func (s XPSummaryState) AreLevelUpsRemaining() bool {
+	for _, actor := range s.Party {
+		if !actor.IsKOed() && actor.ReadyToLevelUp() {
+			return true
+		}
+	}
+	return false
+}
+
+//ApplyAPToParty AP is given at once, mastered abilities pop up
+func (s *XPSummaryState) ApplyAPToParty(ap float64) {
+	if ap <= 0 {
+		return
+	}
+	for k, actor := range s.Party {
+		if actor.IsKOed() {
+			continue
+		}
+		for _, t := range actor.AddAP(ap) {
+			msg := fmt.Sprintf("Mastered %s", combat.AbilityName(t))
+			s.PartySummary[k].AddPopUp(msg, "#ffd025")
 		}
 	}
 }
@@ -209,9 +278,8 @@ func (s *XPSummaryState) ApplyXPToParty(xp float64) {
 func (s *XPSummaryState) SkipCountingXP() {
 	s.IsCountingXP = false
 	s.XPCounter = 0
-	xpToApply := s.XP
 	s.XP = 0
-	s.ApplyXPToParty(xpToApply)
+	s.ApplyXPToParty()
 }
 
 func (s XPSummaryState) ArePopUpsRemaining() bool {
@@ -233,7 +301,7 @@ func (s *XPSummaryState) CloseNextPopUp() {
 
 func (s *XPSummaryState) GotoLootSummary() {
 	world_ := reflect.ValueOf(s.Stack.Globals["world"]).Interface().(*combat.WorldExtended)
-	lootSummaryState := LootSummaryStateCreate(s.Stack, s.win, world_, s.CombatData, s.OnWinCallback)
+	lootSummaryState := LootSummaryStateCreate(s.Stack, s.win, world_, s.CombatData, s.Rewards, s.OnWinCallback)
 
 	storyboardEvents := []interface{}{
 		Wait(0),
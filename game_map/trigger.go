@@ -4,6 +4,8 @@ type Trigger struct {
 	OnEnter func(gMap *GameMap, entity *Entity, x, y float64)
 	OnExit  func(gMap *GameMap, entity *Entity, x, y float64)
 	OnUse   func(gMap *GameMap, entity *Entity, x, y float64)
+	//OnUseItem receives the Key Item chosen by player, returns false to reject it
+	OnUseItem func(gMap *GameMap, entity *Entity, x, y float64, itemId int) bool
 }
 
 //TriggerCreate
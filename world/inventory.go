@@ -0,0 +1,195 @@
+package world
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/steelx/go-rpg-cgm/utilz"
+)
+
+//DefaultMaxStack is used when Item.MaxStack is not set
+const DefaultMaxStack = 99
+
+type SortMode int
+
+const (
+	SortByType SortMode = iota
+	SortByName
+	SortByRecent //recently acquired first
+)
+
+var SortModeLabels = []string{
+	"Type",
+	"Name",
+	"Recent",
+}
+
+//ItemCategory groups several ItemType's together
+// e.g. CategoryWeapon -> Weapon, Sword, Dagger, Stave
+type ItemCategory int
+
+const (
+	CategoryAll ItemCategory = iota
+	CategoryUsable
+	CategoryWeapon
+	CategoryArmor
+	CategoryAccessory
+)
+
+var ItemCategoryLabels = []string{
+	"All",
+	"Usable",
+	"Weapons",
+	"Armor",
+	"Accessories",
+}
+
+var itemCategoryTypes = map[ItemCategory][]ItemType{
+	CategoryUsable:    {Usable},
+	CategoryWeapon:    {Weapon, Sword, Dagger, Stave},
+	CategoryArmor:     {Armor, Plate, Leather, Robe},
+	CategoryAccessory: {Accessory},
+}
+
+//Has tells if given ItemType belongs to the category
+func (c ItemCategory) Has(itemT ItemType) bool {
+	if c == CategoryAll {
+		return itemT != Empty
+	}
+	for _, v := range itemCategoryTypes[c] {
+		if v == itemT {
+			return true
+		}
+	}
+	return false
+}
+
+//StackLimit max Count of a single Item in World.Items
+func (i Item) StackLimit() int {
+	if i.MaxStack > 0 {
+		return i.MaxStack
+	}
+	return DefaultMaxStack
+}
+
+func (w *World) FilterItemsByCategory(category ItemCategory) []ItemIndex {
+	list := make([]ItemIndex, 0)
+	for _, v := range w.Items {
+		item := ItemsDB[v.Id]
+		if category.Has(item.ItemType) {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
+//SortItems sorts World.Items and remembers the mode,
+//items with equal sort keys keep their current order
+func (w *World) SortItems(mode SortMode) {
+	w.SortMode = mode
+	sort.SliceStable(w.Items, func(i, j int) bool {
+		a, b := w.Items[i], w.Items[j]
+		defA, defB := ItemsDB[a.Id], ItemsDB[b.Id]
+
+		switch mode {
+		case SortByType:
+			if defA.ItemType != defB.ItemType {
+				return defA.ItemType < defB.ItemType
+			}
+			return defA.Name < defB.Name
+		case SortByName:
+			return defA.Name < defB.Name
+		case SortByRecent:
+			return a.Acquired > b.Acquired
+		}
+		return false
+	})
+}
+
+//NextSortMode cycles through SortModeLabels
+func (w *World) NextSortMode() {
+	w.SortItems((w.SortMode + 1) % SortMode(len(SortModeLabels)))
+}
+
+//StoreItem moves count of itemId from Items into Storage,
+//returns how many were actually moved
+func (w *World) StoreItem(itemId, count int) int {
+	moved := utilz.MinInt(w.ItemCount(itemId), count)
+	if moved <= 0 {
+		return 0
+	}
+
+	w.RemoveItem(itemId, moved)
+	w.Storage = addToStack(w.Storage, itemId, moved)
+	return moved
+}
+
+//WithdrawItem moves count of itemId from Storage back into Items,
+//limited by the Item stack size. Returns how many were actually moved
+func (w *World) WithdrawItem(itemId, count int) int {
+	index := indexOfItem(w.Storage, itemId)
+	if index == -1 {
+		return 0
+	}
+
+	room := mustGetItem(itemId).StackLimit() - w.ItemCount(itemId)
+	moved := utilz.MinInt(utilz.MinInt(w.Storage[index].Count, count), room)
+	if moved <= 0 {
+		return 0
+	}
+
+	w.Storage[index].Count -= moved
+	if w.Storage[index].Count <= 0 {
+		w.Storage = removeItemAtIndex(w.Storage, index)
+	}
+	w.AddItem(itemId, moved)
+	return moved
+}
+
+func (w World) StorageCount(itemId int) int {
+	if index := indexOfItem(w.Storage, itemId); index != -1 {
+		return w.Storage[index].Count
+	}
+	return 0
+}
+
+//ConsumeKeyItem removes Key Item after use, unless it is persistent
+func (w *World) ConsumeKeyItem(itemId int) {
+	if mustGetItem(itemId).Consumable {
+		w.RemoveKeyItem(itemId)
+	}
+}
+
+func mustGetItem(itemId int) Item {
+	item, ok := ItemsDB[itemId]
+	if !ok {
+		log.Fatal(fmt.Sprintf("Item ID {%v} does not exists in DB", itemId))
+	}
+	return item
+}
+
+func indexOfItem(list []ItemIndex, itemId int) int {
+	for i := range list {
+		if list[i].Id == itemId {
+			return i
+		}
+	}
+	return -1
+}
+
+//removeItemAtIndex keeps the order of remaining items
+func removeItemAtIndex(list []ItemIndex, i int) []ItemIndex {
+	return append(list[:i], list[i+1:]...)
+}
+
+//addToStack has no stack limit, used by Storage
+func addToStack(list []ItemIndex, itemId, count int) []ItemIndex {
+	if index := indexOfItem(list, itemId); index != -1 {
+		list[index].Count += count
+		return list
+	}
+	return append(list, ItemIndex{Id: itemId, Count: count})
+}
+
@@ -0,0 +1,216 @@
+package world
+
+import (
+	"testing"
+)
+
+func itemIds(list []ItemIndex) []int {
+	var ids []int
+	for _, v := range list {
+		ids = append(ids, v.Id)
+	}
+	return ids
+}
+
+func sameIds(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAddItemStacks(t *testing.T) {
+	w := Create()
+	w.AddItem(11, 2)
+	w.AddItem(12, 1)
+	w.AddItem(11, 3)
+
+	if len(w.Items) != 2 {
+		t.Fatalf("expected 2 stacks, got %v", w.Items)
+	}
+	if w.ItemCount(11) != 5 {
+		t.Errorf("expected 5 Heal Potions, got %v", w.ItemCount(11))
+	}
+}
+
+func TestRemoveItemKeepsOrder(t *testing.T) {
+	w := Create()
+	w.AddItem(11, 1)
+	w.AddItem(12, 1)
+	w.AddItem(13, 1)
+	w.AddItem(3, 1)
+
+	w.RemoveItem(12, 1)
+	if got, want := itemIds(w.Items), []int{11, 13, 3}; !sameIds(got, want) {
+		t.Errorf("order changed after remove: got %v want %v", got, want)
+	}
+
+	w.RemoveItem(11, 1)
+	if got, want := itemIds(w.Items), []int{13, 3}; !sameIds(got, want) {
+		t.Errorf("order changed after remove: got %v want %v", got, want)
+	}
+}
+
+func TestRemoveItemPartialStack(t *testing.T) {
+	w := Create()
+	w.AddItem(11, 3)
+	w.AddItem(12, 1)
+
+	w.RemoveItem(11, 1)
+	if w.ItemCount(11) != 2 {
+		t.Errorf("expected 2 Heal Potions, got %v", w.ItemCount(11))
+	}
+	if w.ItemCount(12) != 1 {
+		t.Errorf("removing Heal Potion changed Mana Potion count to %v", w.ItemCount(12))
+	}
+
+	//removing something not in inventory is a no-op
+	w.RemoveItem(13, 1)
+	if len(w.Items) != 2 {
+		t.Errorf("expected 2 stacks, got %v", w.Items)
+	}
+}
+
+func TestStackLimitOverflowsToStorage(t *testing.T) {
+	w := Create()
+	limit := ItemsDB[14].StackLimit()
+	w.AddItem(14, limit+3)
+
+	if w.ItemCount(14) != limit {
+		t.Errorf("expected stack capped at %v, got %v", limit, w.ItemCount(14))
+	}
+	if w.StorageCount(14) != 3 {
+		t.Errorf("expected 3 in storage, got %v", w.StorageCount(14))
+	}
+
+	w.RemoveItem(14, 5)
+	moved := w.WithdrawItem(14, 10)
+	if moved != 3 || w.ItemCount(14) != limit-2 || w.StorageCount(14) != 0 {
+		t.Errorf("withdraw moved %v, inventory %v, storage %v", moved, w.ItemCount(14), w.StorageCount(14))
+	}
+	if len(w.Storage) != 0 {
+		t.Errorf("expected empty storage, got %v", w.Storage)
+	}
+}
+
+func TestWithdrawRespectsStackLimit(t *testing.T) {
+	w := Create()
+	limit := ItemsDB[14].StackLimit()
+	w.AddItem(14, limit*2)
+
+	if moved := w.WithdrawItem(14, limit); moved != 0 {
+		t.Errorf("stack is full, expected nothing withdrawn, got %v", moved)
+	}
+
+	w.StoreItem(14, 4)
+	if w.ItemCount(14) != limit-4 || w.StorageCount(14) != limit+4 {
+		t.Errorf("store: inventory %v, storage %v", w.ItemCount(14), w.StorageCount(14))
+	}
+}
+
+func TestKeyItems(t *testing.T) {
+	w := Create()
+	w.AddItem(11, 1)
+	w.AddKeyItem(4)
+	w.AddKeyItem(4)
+	if len(w.KeyItems) != 1 {
+		t.Fatalf("key item added twice: %v", w.KeyItems)
+	}
+
+	w.AddKeyItem(13)
+	w.AddKeyItem(3)
+
+	//removing an unknown key item is a no-op
+	w.RemoveKeyItem(99)
+	if len(w.KeyItems) != 3 {
+		t.Errorf("expected 3 key items, got %v", w.KeyItems)
+	}
+
+	w.RemoveKeyItem(13)
+	if got, want := itemIds(w.KeyItems), []int{4, 3}; !sameIds(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	if !w.HasKey(4) || w.HasKey(13) {
+		t.Errorf("HasKey mismatch after remove: %v", w.KeyItems)
+	}
+
+	w.RemoveKeyItem(4)
+	w.RemoveKeyItem(3)
+	if len(w.KeyItems) != 0 {
+		t.Errorf("expected no key items, got %v", w.KeyItems)
+	}
+	if w.ItemCount(11) != 1 {
+		t.Errorf("removing key items touched Items: %v", w.Items)
+	}
+}
+
+func TestConsumeKeyItem(t *testing.T) {
+	w := Create()
+	w.AddKeyItem(4)
+	w.ConsumeKeyItem(4)
+	if !w.HasKey(4) {
+		t.Errorf("persistent key item was removed")
+	}
+
+	ItemsDB[99] = Item{Id: 99, Name: "Rusty Key", Consumable: true}
+	defer delete(ItemsDB, 99)
+	w.AddKeyItem(99)
+	w.ConsumeKeyItem(99)
+	if w.HasKey(99) {
+		t.Errorf("consumable key item was not removed: %v", w.KeyItems)
+	}
+}
+
+func TestSortItems(t *testing.T) {
+	w := Create()
+	w.AddItem(12, 1) //Mana Potion, Usable
+	w.AddItem(3, 1)  //Ring of Titan, Accessory
+	w.AddItem(11, 1) //Heal Potion, Usable
+	w.AddItem(1, 1)  //Bone Blade, Weapon
+
+	w.SortItems(SortByName)
+	if got, want := itemIds(w.Items), []int{1, 11, 12, 3}; !sameIds(got, want) {
+		t.Errorf("SortByName got %v want %v", got, want)
+	}
+
+	w.SortItems(SortByType)
+	if got, want := itemIds(w.Items), []int{11, 12, 3, 1}; !sameIds(got, want) {
+		t.Errorf("SortByType got %v want %v", got, want)
+	}
+
+	w.AddItem(3, 1)
+	w.SortItems(SortByRecent)
+	if got, want := itemIds(w.Items), []int{3, 1, 11, 12}; !sameIds(got, want) {
+		t.Errorf("SortByRecent got %v want %v", got, want)
+	}
+}
+
+func TestFilterItemsByCategory(t *testing.T) {
+	w := Create()
+	w.AddItem(11, 1)
+	w.AddItem(1, 1)
+	w.AddItem(2, 1)
+	w.AddItem(3, 1)
+
+	tests := []struct {
+		category ItemCategory
+		want     []int
+	}{
+		{CategoryAll, []int{11, 1, 2, 3}},
+		{CategoryUsable, []int{11}},
+		{CategoryWeapon, []int{1}},
+		{CategoryArmor, []int{2}},
+		{CategoryAccessory, []int{3}},
+	}
+	for _, test := range tests {
+		got := itemIds(w.FilterItemsByCategory(test.category))
+		if !sameIds(got, test.want) {
+			t.Errorf("%s got %v want %v", ItemCategoryLabels[test.category], got, test.want)
+		}
+	}
+}
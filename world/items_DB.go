@@ -10,10 +10,31 @@ type Item struct {
 	Name, Description string
 	Special           bool
 	Stats             Mod
-	Restrictions      []string //e.g. {"hero","mage",}
+	Restrictions      []string //Actor ids e.g. {"hero","mage",}, whatever their job
+	Jobs              []string //combat.JobsDB keys, an item restricted by neither fits anyone
 	Use               UseAction
 	Icon              int
-	Oddment           float64 //chances of finding
+	Oddment           float64  //chances of finding
+	MaxStack          int      //0 means DefaultMaxStack
+	Consumable        bool     //Key Item is used up once a Trigger accepts it
+	TwoHanded         bool     //blocks the slot named by combat.EquipSlot.Blocks
+	Ranged            bool     //melee damage ignores rows, see combat.RowMultiplier
+	Element           string   //weapons only, melee damage of this element e.g. SpellFire
+	Passives          []string //PassivesDB ids active while equipped
+	Teaches           []Teachable
+}
+
+//Modifier equipment stats of item, copies in two slots both count
+func (i Item) Modifier() Modifier {
+	return Modifier{Name: i.Name, UniqueId: i.Id, Mod: i.Stats, Stack: StackAdd}
+}
+
+//Teachable ability granted while an Item is equipped,
+//it is mastered for good once the wearer earns enough AP
+type Teachable struct {
+	Action string  //combat.ActionMagic, ActionSpecial or ActionPassive
+	Id     string  //SpellsDB, SpecialsDB or PassivesDB key
+	AP     float64 //ability points needed to master it
 }
 
 type Action int
@@ -25,6 +46,13 @@ const (
 	ElementSpell
 	ElementSlash
 	ElementSteal
+	ItemDamage //e.g. bombs
+	CureStatus
+	StatBuff
+	Escape
+	Scan
+	LimitStrike //SpecialItem.Hits attacks on random enemies
+	LimitHeal   //restores HP of the whole party
 )
 
 //below should match to Key of Co
@@ -34,6 +62,8 @@ const (
 	MostDrainedParty  = "MostDrainedParty" //lowest MP
 	MostHurtEnemy     = "MostHurtEnemy"
 	DeadParty         = "DeadParty"
+	AfflictedParty    = "AfflictedParty" //first one suffering from a Status
+	SideParty         = "SideParty"
 	RandomAlivePlayer = "RandomAlivePlayer"
 	WeakestEnemy      = "WeakestEnemy"
 	SideEnemy         = "SideEnemy"
@@ -57,6 +87,10 @@ type ItemTarget struct {
 type UseAction struct {
 	Action  Action
 	Restore float64
+	Damage  [2]float64 //ItemDamage range min, max
+	Element string     //ItemDamage, same as SpecialItem.Element e.g. SpellFire
+	Cures   []string   //CureStatus e.g. StatusPoison
+	Buff    Modifier   //StatBuff, removed once combat is over
 	Target  ItemTarget
 	Hint    string
 }
@@ -107,11 +141,12 @@ func init() {
 
 	ItemsDB[1] = Item{
 		Id:           1,
-		ItemType:     Weapon,
+		ItemType:     Sword,
 		Name:         "Bone Blade",
 		Description:  "A wicked sword made from bone.",
 		Icon:         5,
 		Restrictions: []string{"hero"},
+		Jobs:         []string{"warrior"},
 		Stats: Mod{
 			Add: BaseStats{
 				Attack: 5,
@@ -121,12 +156,13 @@ func init() {
 
 	ItemsDB[2] = Item{
 		Id:           2,
-		ItemType:     Armor,
+		ItemType:     Plate,
 		Oddment:      1,
 		Name:         "Bone Armor",
 		Description:  "Armor made from plates of blackened bone.",
 		Icon:         7,
 		Restrictions: []string{"hero"},
+		Jobs:         []string{"warrior"},
 		Stats: Mod{
 			Add: BaseStats{
 				Defense: 5,
@@ -158,12 +194,12 @@ func init() {
 	}
 
 	ItemsDB[5] = Item{
-		Id:           5,
-		ItemType:     Weapon,
-		Name:         "World Tree Branch",
-		Description:  "A hard wood branch.",
-		Icon:         6,
-		Restrictions: []string{"mage"},
+		Id:          5,
+		ItemType:    Stave,
+		Name:        "World Tree Branch",
+		Description: "A hard wood branch.",
+		Icon:        6,
+		Jobs:        []string{"mage"},
 		Stats: Mod{
 			Add: BaseStats{
 				Attack: 2,
@@ -178,7 +214,9 @@ func init() {
 		Name:         "Dragon's Cloak",
 		Description:  "A cloak of dragon scales.",
 		Icon:         8,
-		Restrictions: []string{"mage", "hero"},
+		Restrictions: []string{"hero"},
+		Jobs:         []string{"mage", "warrior"},
+		Teaches:      []Teachable{{Action: "Magic", Id: SpellHeal, AP: 30}},
 		Stats: Mod{
 			Add: BaseStats{
 				Defense: 3,
@@ -201,12 +239,12 @@ func init() {
 	}
 
 	ItemsDB[8] = Item{
-		Id:           8,
-		ItemType:     Weapon,
-		Name:         "Black Dagger",
-		Description:  "A dagger made out of an unknown material.",
-		Icon:         5,
-		Restrictions: []string{"thief"},
+		Id:          8,
+		ItemType:    Dagger,
+		Name:        "Black Dagger",
+		Description: "A dagger made out of an unknown material.",
+		Icon:        5,
+		Jobs:        []string{"thief"},
 		Stats: Mod{
 			Add: BaseStats{
 				Attack: 4,
@@ -215,12 +253,12 @@ func init() {
 	}
 
 	ItemsDB[9] = Item{
-		Id:           9,
-		ItemType:     Armor,
-		Name:         "Footpad Leathers",
-		Description:  "Light Armor for silent movement.",
-		Icon:         7,
-		Restrictions: []string{"thief"},
+		Id:          9,
+		ItemType:    Leather,
+		Name:        "Footpad Leathers",
+		Description: "Light Armor for silent movement.",
+		Icon:        7,
+		Jobs:        []string{"thief"},
 		Stats: Mod{
 			Add: BaseStats{
 				Defense: 4,
@@ -233,8 +271,9 @@ func init() {
 		Oddment:     1,
 		ItemType:    Accessory,
 		Name:        "Swift Boots",
-		Description: "Increases speed by 25%",
+		Description: "Increases speed by 25%, strikes first.",
 		Icon:        9,
+		Teaches:     []Teachable{{Action: "Passive", Id: PassiveFirstStrike, AP: 60}},
 		Stats: Mod{
 			Mult: BaseStats{
 				Speed: 0.25,
@@ -256,7 +295,7 @@ func init() {
 				SwitchSides: false,
 				Type:        CombatTargetTypeONE,
 			},
-			Hint: "Choose target to revive.",
+			Hint: "Choose target to heal.",
 		},
 	}
 
@@ -296,6 +335,7 @@ func init() {
 		Name:        "Life salve",
 		Description: "Restore a character from the brink of death",
 		Icon:        1,
+		MaxStack:    10,
 		Use: UseAction{
 			Action:  Revive,
 			Restore: 100,
@@ -307,4 +347,251 @@ func init() {
 			Hint: "Choose target to revive.",
 		},
 	}
+
+	ItemsDB[15] = Item{
+		Id:          15,
+		ItemType:    Usable,
+		Name:        "Fire Bomb",
+		Description: "Explodes in flames, burning a single enemy.",
+		Use: UseAction{
+			Action:  ItemDamage,
+			Damage:  [2]float64{30, 45},
+			Element: SpellFire,
+			Target: ItemTarget{
+				Selector:    WeakestEnemy,
+				SwitchSides: true,
+				Type:        CombatTargetTypeONE,
+			},
+			Hint: "Choose target to attack.",
+		},
+	}
+
+	ItemsDB[16] = Item{
+		Id:          16,
+		ItemType:    Usable,
+		Name:        "Ice Bomb",
+		Description: "Shatters into ice shards, hitting all enemies.",
+		Use: UseAction{
+			Action:  ItemDamage,
+			Damage:  [2]float64{15, 25},
+			Element: SpellIce,
+			Target: ItemTarget{
+				Selector:    SideEnemy,
+				SwitchSides: true,
+				Type:        CombatTargetTypeSIDE,
+			},
+			Hint: "Choose side to attack.",
+		},
+	}
+
+	ItemsDB[17] = Item{
+		Id:          17,
+		ItemType:    Usable,
+		Name:        "Antidote",
+		Description: "Cures poison.",
+		Use: UseAction{
+			Action: CureStatus,
+			Cures:  []string{StatusPoison},
+			Target: ItemTarget{
+				Selector:    AfflictedParty,
+				SwitchSides: false,
+				Type:        CombatTargetTypeONE,
+			},
+			Hint: "Choose target to cure.",
+		},
+	}
+
+	ItemsDB[18] = Item{
+		Id:          18,
+		ItemType:    Usable,
+		Name:        "Remedy",
+		Description: "Cures all status ailments.",
+		Use: UseAction{
+			Action: CureStatus,
+			Cures:  AllStatuses,
+			Target: ItemTarget{
+				Selector:    AfflictedParty,
+				SwitchSides: false,
+				Type:        CombatTargetTypeONE,
+			},
+			Hint: "Choose target to cure.",
+		},
+	}
+
+	ItemsDB[19] = Item{
+		Id:          19,
+		ItemType:    Usable,
+		Name:        "Power Drink",
+		Description: "Raises Strength until the end of battle.",
+		Use: UseAction{
+			Action: StatBuff,
+			Buff: Modifier{
+				Name:     "Power Drink",
+				UniqueId: 1019,
+				Mod: Mod{
+					Add: BaseStats{
+						Strength: 10,
+					},
+				},
+			},
+			Target: ItemTarget{
+				Selector:    RandomAlivePlayer,
+				SwitchSides: false,
+				Type:        CombatTargetTypeONE,
+			},
+			Hint: "Choose target to power up.",
+		},
+	}
+
+	ItemsDB[20] = Item{
+		Id:          20,
+		ItemType:    Usable,
+		Name:        "Smoke Bomb",
+		Description: "Lets the party escape from battle.",
+		Use: UseAction{
+			Action: Escape,
+			Target: ItemTarget{
+				Selector:    SideParty,
+				SwitchSides: false,
+				Type:        CombatTargetTypeSIDE,
+			},
+			Hint: "Escape from battle.",
+		},
+	}
+
+	ItemsDB[21] = Item{
+		Id:          21,
+		ItemType:    Usable,
+		Name:        "Scan Lens",
+		Description: "Reveals an enemy's stats.",
+		Use: UseAction{
+			Action: Scan,
+			Target: ItemTarget{
+				Selector:    WeakestEnemy,
+				SwitchSides: true,
+				Type:        CombatTargetTypeONE,
+			},
+			Hint: "Choose target to scan.",
+		},
+	}
+
+	ItemsDB[22] = Item{
+		Id:           22,
+		ItemType:     Sword,
+		Name:         "Great Sword",
+		Description:  "A heavy blade, needs both hands.",
+		Icon:         5,
+		Restrictions: []string{"hero"},
+		Jobs:         []string{"warrior"},
+		TwoHanded:    true,
+		Stats: Mod{
+			Add: BaseStats{
+				Attack: 12,
+			},
+		},
+	}
+
+	ItemsDB[23] = Item{
+		Id:           23,
+		ItemType:     Dagger,
+		Name:         "Parrying Dagger",
+		Description:  "Held in the off hand to turn blows aside.",
+		Icon:         5,
+		Restrictions: []string{"hero"},
+		Jobs:         []string{"warrior", "thief"},
+		Stats: Mod{
+			Add: BaseStats{
+				Attack:  2,
+				Defense: 3,
+			},
+		},
+	}
+
+	ItemsDB[24] = Item{
+		Id:          24,
+		ItemType:    Accessory,
+		Name:        "Troll Ring",
+		Description: "Slowly heals wounds in battle.",
+		Icon:        2,
+		Teaches:     []Teachable{{Action: "Passive", Id: PassiveRegen, AP: 80}},
+	}
+
+	ItemsDB[25] = Item{
+		Id:          25,
+		ItemType:    Accessory,
+		Name:        "Sage's Pendant",
+		Description: "Spells cost less MP.",
+		Icon:        1,
+		Jobs:        []string{"mage"},
+		Passives:    []string{PassiveMpSaver},
+	}
+
+	ItemsDB[26] = Item{
+		Id:          26,
+		ItemType:    Accessory,
+		Name:        "Velvet Glove",
+		Description: "Steals succeed more often.",
+		Icon:        2,
+		Jobs:        []string{"thief"},
+		Passives:    []string{PassiveStealBonus},
+	}
+
+	ItemsDB[27] = Item{
+		Id:          27,
+		ItemType:    Accessory,
+		Name:        "Scholar's Charm",
+		Description: "Doubles experience earned.",
+		Icon:        1,
+		Passives:    []string{PassiveDoubleXP},
+	}
+
+	ItemsDB[28] = Item{
+		Id:          28,
+		ItemType:    Accessory,
+		Name:        "Serpent Band",
+		Description: "Protects from poison.",
+		Icon:        2,
+		Teaches:     []Teachable{{Action: "Passive", Id: PassiveImmunePoison, AP: 40}},
+	}
+
+	ItemsDB[29] = Item{
+		Id:          29,
+		ItemType:    Accessory,
+		Name:        "Lucky Coin",
+		Description: "More gold after battle.",
+		Icon:        1,
+		Passives:    []string{PassiveGoldBonus},
+	}
+
+	ItemsDB[30] = Item{
+		Id:           30,
+		ItemType:     Weapon,
+		Name:         "Hunting Bow",
+		Description:  "Hits just as hard from the back row.",
+		Icon:         5,
+		Restrictions: []string{"hero"},
+		Jobs:         []string{"thief"},
+		Ranged:       true,
+		Stats: Mod{
+			Add: BaseStats{
+				Attack: 6,
+			},
+		},
+	}
+
+	ItemsDB[31] = Item{
+		Id:           31,
+		ItemType:     Sword,
+		Name:         "Frost Brand",
+		Description:  "An icy blade, its cuts freeze.",
+		Icon:         5,
+		Restrictions: []string{"hero"},
+		Jobs:         []string{"warrior"},
+		Element:      SpellIce,
+		Stats: Mod{
+			Add: BaseStats{
+				Attack: 8,
+			},
+		},
+	}
 }
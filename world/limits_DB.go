@@ -0,0 +1,41 @@
+package world
+
+//Limits are specials unlocked once their owner's limit gauge is full, see combat.ActorDef.Limit
+const (
+	LimitBraveBlade  = "BraveBlade"
+	LimitSanctuary   = "Sanctuary"
+	LimitShadowDance = "ShadowDance"
+)
+
+var LimitsDB = map[string]SpecialItem{
+	LimitBraveBlade: {
+		Name:   "Brave Blade",
+		Action: LimitStrike,
+		Hits:   4,
+		Target: ItemTarget{
+			Selector:    SideEnemy,
+			SwitchSides: false,
+			Type:        CombatTargetTypeSIDE,
+		},
+	},
+	LimitSanctuary: {
+		Name:    "Sanctuary",
+		Action:  LimitHeal,
+		Restore: 250,
+		Target: ItemTarget{
+			Selector:    SideParty,
+			SwitchSides: false,
+			Type:        CombatTargetTypeSIDE,
+		},
+	},
+	LimitShadowDance: {
+		Name:   "Shadow Dance",
+		Action: LimitStrike,
+		Hits:   3,
+		Target: ItemTarget{
+			Selector:    SideEnemy,
+			SwitchSides: false,
+			Type:        CombatTargetTypeSIDE,
+		},
+	},
+}
@@ -0,0 +1,77 @@
+package world
+
+//Passive abilities are always on, they come from equipped Item.Passives,
+//ActionGrowth unlocks or StatusPassives. Same passives from
+//several sources stack by adding up their Value
+const (
+	PassiveRegen        = "Regen"        //Value fraction of HpMax restored every turn
+	PassiveMpSaver      = "MpSaver"      //Value fraction of MP cost saved
+	PassiveFirstStrike  = "FirstStrike"  //first turn of battle comes at once
+	PassiveCounterBoost = "CounterBoost" //Value added to Counter chance
+	PassiveStealBonus   = "StealBonus"   //Value added to chance to steal
+	PassiveImmunePoison = "ImmunePoison"
+	PassiveImmuneAll    = "ImmuneAll"
+	PassiveDoubleXP     = "DoubleXP"
+	PassiveGoldBonus    = "GoldBonus" //Value added to gold multiplier after battle
+)
+
+type Passive struct {
+	Id, Name, Description string
+	Value                 float64
+	Immune                []string //statuses which can't be inflicted e.g. StatusPoison
+}
+
+var PassivesDB = map[string]Passive{
+	PassiveRegen: {
+		Id:          PassiveRegen,
+		Name:        "Regen",
+		Description: "Restores HP every turn.",
+		Value:       0.05,
+	},
+	PassiveMpSaver: {
+		Id:          PassiveMpSaver,
+		Name:        "MP Saver",
+		Description: "Spells and specials cost less MP.",
+		Value:       0.25,
+	},
+	PassiveFirstStrike: {
+		Id:          PassiveFirstStrike,
+		Name:        "First Strike",
+		Description: "Always acts first in battle.",
+	},
+	PassiveCounterBoost: {
+		Id:          PassiveCounterBoost,
+		Name:        "Counter+",
+		Description: "Counters attacks more often.",
+		Value:       0.25,
+	},
+	PassiveStealBonus: {
+		Id:          PassiveStealBonus,
+		Name:        "Nimble Fingers",
+		Description: "Steals succeed more often.",
+		Value:       0.2,
+	},
+	PassiveImmunePoison: {
+		Id:          PassiveImmunePoison,
+		Name:        "Poison Ward",
+		Description: "Can't be poisoned.",
+		Immune:      []string{StatusPoison},
+	},
+	PassiveImmuneAll: {
+		Id:          PassiveImmuneAll,
+		Name:        "Pure Soul",
+		Description: "Immune to all status ailments.",
+		Immune:      AllStatuses,
+	},
+	PassiveDoubleXP: {
+		Id:          PassiveDoubleXP,
+		Name:        "Double XP",
+		Description: "Earns twice the experience.",
+	},
+	PassiveGoldBonus: {
+		Id:          PassiveGoldBonus,
+		Name:        "Gold Finder",
+		Description: "Finds more gold after battle.",
+		Value:       0.25,
+	},
+}
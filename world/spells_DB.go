@@ -19,6 +19,8 @@ const (
 	SpellBurn = "Burn"
 	SpellIce  = "Ice"
 	SpellBolt = "Bolt"
+	SpellHeal = "Heal"
+	SpellLife = "Life"
 )
 
 // SpecialItem
@@ -32,8 +34,10 @@ type SpecialItem struct {
 	BaseHitChance,
 	TimePoints float64
 	BaseDamage [2]float64 // multiplied by level
+	Restore    float64    //HpRestore, MpRestore & Revive spells
 	Target     ItemTarget
 	Counter    bool
+	Hits       int //LimitStrike only
 }
 
 // spell cast time 1 is base, 2 is twice as long etc
@@ -101,4 +105,34 @@ var SpellsDB = map[string]SpecialItem{
 			Type:        CombatTargetTypeONE,
 		},
 	},
+
+	SpellHeal: {
+		Name:          "Heal",
+		Action:        HpRestore,
+		MpCost:        6,
+		CastTime:      0.6,
+		Restore:       60,
+		BaseHitChance: 1,
+		TimePoints:    10,
+		Target: ItemTarget{
+			Selector:    MostHurtParty,
+			SwitchSides: false,
+			Type:        CombatTargetTypeONE,
+		},
+	},
+
+	SpellLife: {
+		Name:          "Life",
+		Action:        Revive,
+		MpCost:        20,
+		CastTime:      1,
+		Restore:       50,
+		BaseHitChance: 1,
+		TimePoints:    20,
+		Target: ItemTarget{
+			Selector:    DeadParty,
+			SwitchSides: false,
+			Type:        CombatTargetTypeONE,
+		},
+	},
 }
@@ -2,9 +2,7 @@ package world
 
 import (
 	"fmt"
-	"reflect"
-
-	"github.com/fatih/structs"
+	"log"
 )
 
 /*
@@ -66,35 +64,110 @@ type Modifier struct {
 	Name     string
 	UniqueId int
 	Mod      Mod
+	Stack    StackPolicy //when applied again, see Stats.Apply
+	Turns    int         //expires after the owner's turns, 0 means never
+	Seconds  float64     //expires after combat time, 0 means never
 }
+
+//Mod applied in phases: (base + Add) * (1 + sum of Mult) * (1 + Final) for each Final
 type Mod struct {
-	Add  BaseStats
-	Mult BaseStats
+	Add   BaseStats
+	Mult  BaseStats //percent, 0.5 is +50%
+	Final BaseStats //final multiplier, 0.5 is x1.5
 }
 
-type Stats struct {
-	Base      map[string]float64
-	Modifiers map[int]Mod
+//StatId typed key of a stat, BaseStats fields in order followed by DerivedStatsDB ids
+type StatId int
+
+const (
+	StatHpNow StatId = iota
+	StatHpMax
+	StatMpNow
+	StatMpMax
+	StatStrength
+	StatSpeed
+	StatIntelligence
+	StatAttack
+	StatDefense
+	StatMagic
+	StatResist
+	StatCounter
+	StatFire
+	StatBurn
+	StatIce
+	StatBolt
+	StatLevel
+	StatHitChance //derived stats from here on
+	StatCritChance
+	StatEvasion
+	StatMagicDefense
+	StatCount
+)
+
+var statNames = [StatCount]string{
+	"HpNow", "HpMax", "MpNow", "MpMax",
+	"Strength", "Speed", "Intelligence",
+	"Attack", "Defense", "Magic", "Resist",
+	"Counter", "Fire", "Burn", "Ice", "Bolt",
+	"Level",
+	"HitChance", "CritChance", "Evasion", "MagicDefense",
 }
 
-func StatsCreate(stats BaseStats) Stats {
-	s := Stats{
-		Base:      make(map[string]float64),
-		Modifiers: make(map[int]Mod),
+var statIds = make(map[string]StatId)
+
+func init() {
+	for id, name := range statNames {
+		statIds[name] = StatId(id)
 	}
+}
 
-	//Iterate through the fields of a struct
-	v := reflect.ValueOf(stats)
-	typeOfS := v.Type()
+//ParseStat e.g. "Strength" -> StatStrength, for string keyed data
+func ParseStat(name string) (StatId, bool) {
+	id, ok := statIds[name]
+	return id, ok
+}
+
+func (id StatId) String() string {
+	return statNames[id]
+}
 
-	for i := 0; i < v.NumField(); i++ {
-		val := v.Field(i).Interface().(float64)
-		s.Base[typeOfS.Field(i).Name] = val
+//IsDerived computed by DerivedStatsDB, can't be Set
+func (id StatId) IsDerived() bool {
+	return id >= StatHitChance
+}
+
+type statValues [StatCount]float64
+
+func (b BaseStats) values() statValues {
+	return statValues{
+		b.HpNow, b.HpMax, b.MpNow, b.MpMax,
+		b.Strength, b.Speed, b.Intelligence,
+		b.Attack, b.Defense, b.Magic, b.Resist,
+		b.Counter, b.Fire, b.Burn, b.Ice, b.Bolt,
+		b.Level,
 	}
-	return s
 }
 
-//AddModifier
+//Stats copies share the same values, like the maps they replaced did
+type Stats struct {
+	d *statsData
+}
+
+type statsData struct {
+	base    statValues
+	applied []appliedMod //in the order they were applied
+	totals  statValues   //cached Value results
+	cached  [StatCount]bool
+}
+
+func StatsCreate(stats BaseStats) Stats {
+	return Stats{d: &statsData{
+		base: stats.values(),
+	}}
+}
+
+//AddModifier with no source, replaces a previous one with the same uniqueId.
+//Prefer Apply, which tells where the modifier comes from
 /*
 magic_sword := Modifier{
 		UniqueId: 1,
@@ -107,39 +180,110 @@ magic_sword := Modifier{
 	}
 */
 func (s *Stats) AddModifier(uniqueId int, modifier Mod) {
-	s.Modifiers[uniqueId] = modifier
+	s.Apply(ModSource{}, Modifier{UniqueId: uniqueId, Mod: modifier})
 }
+
+//RemoveModifier every modifier with uniqueId, whatever its source
 func (s *Stats) RemoveModifier(uniqueId int) {
-	delete(s.Modifiers, uniqueId)
+	s.remove(func(a AppliedMod) bool {
+		return a.Modifier.UniqueId == uniqueId
+	})
+}
+
+func (s Stats) HasModifier(uniqueId int) bool {
+	for _, a := range s.d.applied {
+		if a.Modifier.UniqueId == uniqueId {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Stats) invalidate() {
+	s.d.cached = [StatCount]bool{}
+}
+
+//Value base plus modifiers Add, then times 1 + modifiers Mult, then times
+//each 1 + Final, kept within StatCaps. Totals are cached until stats change
+func (s Stats) Value(id StatId) float64 {
+	d := s.d
+	if d.cached[id] {
+		return d.totals[id]
+	}
+
+	var value float64
+	if id.IsDerived() {
+		value = DerivedStatsDB[id].Formula(s)
+	} else {
+		total := d.base[id] //10
+		multiplier, final := 0.0, 1.0
+		for i, m := range d.applied {
+			if !d.counts(i, id) {
+				continue
+			}
+			total += m.add[id]       //+ 5
+			multiplier += m.mult[id] //+ 2
+			final *= 1 + m.final[id] //* 1
+		}
+		value = (total + (total * multiplier)) * final //(15 + (15*2)) * 1 == 45
+	}
+
+	value = capStat(id, value)
+	d.totals[id] = value
+	d.cached[id] = true
+	return value
 }
 
-//Get id = BaseStats.KEY e.g. Get("Strength")
+//Get id = BaseStats.KEY e.g. Get("Strength") or a DerivedStatsDB key,
+//prefer Value in code, Get is for string keyed data.
+//Unknown ids are logged and read as 0, see Lookup
 func (s Stats) Get(id string) float64 {
-	total, ok := s.Base[id] //10
+	value, ok := s.Lookup(id)
 	if !ok {
-		panic(fmt.Sprintf("stats.go: '%v' not defined on BaseStats", id))
+		log.Printf("stats.go: '%v' not defined on BaseStats", id)
 	}
-	multiplier := 0.0
-
-	for _, modifier := range s.Modifiers {
-		add := structs.Map(modifier.Add)
-		addVal := reflect.ValueOf(add[id])    //e.g. modifier.Add.Strength if id = Strength
-		total += addVal.Interface().(float64) //+ 5
+	return value
+}
 
-		mult := structs.Map(modifier.Mult)
-		multVal := reflect.ValueOf(mult[id])        //e.g. modifier.Mult.Strength if id = Strength
-		multiplier += multVal.Interface().(float64) //+ 2
+//Lookup like Get, ok is false if id is not a stat e.g. an element without
+//a resistance stat, for data which may name anything
+func (s Stats) Lookup(id string) (float64, bool) {
+	statId, ok := ParseStat(id)
+	if !ok {
+		return 0, false
 	}
+	return s.Value(statId), true
+}
 
-	return total + (total * multiplier) //15 + (15*2) == 45
+func (s Stats) BaseValue(id StatId) float64 {
+	return s.d.base[id]
 }
 
 func (s Stats) GetBaseStat(id string) float64 {
-	return s.Base[id]
+	if statId, ok := ParseStat(id); ok {
+		return s.BaseValue(statId)
+	}
+	return 0
+}
+
+//SetValue base value of id, derived stats can't be set
+func (s *Stats) SetValue(id StatId, val float64) {
+	if id.IsDerived() {
+		panic(fmt.Sprintf("stats.go: derived stat '%v' can't be set", id))
+	}
+	s.d.base[id] = val
+	s.invalidate()
 }
 
 //Set e.g. Set("HpNow", 50)
-//In combat, the HpNow and MpNow stats often change
-func (s *Stats) Set(baseStatId string, val float64) {
-	s.Base[baseStatId] = val
+//In combat, the HpNow and MpNow stats often change.
+//Unknown ids are logged and ignored like in Get, ok is false then
+func (s *Stats) Set(baseStatId string, val float64) bool {
+	statId, ok := ParseStat(baseStatId)
+	if !ok {
+		log.Printf("stats.go: '%v' not defined on BaseStats", baseStatId)
+		return false
+	}
+	s.SetValue(statId, val)
+	return true
 }
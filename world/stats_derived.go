@@ -0,0 +1,119 @@
+package world
+
+import "math"
+
+//DerivedStat computed from other stats e.g. CritChance = 0.05 + Speed/500,
+//read with Stats.Value like any other StatId
+type DerivedStat struct {
+	Id      StatId
+	Label   string
+	Formula func(s Stats) float64
+}
+
+var DerivedStatOrder = []StatId{StatHitChance, StatCritChance, StatEvasion, StatMagicDefense}
+
+var DerivedStatsDB map[StatId]DerivedStat
+
+//formulas read Stats.Value which reads DerivedStatsDB, hence init
+func init() {
+	DerivedStatsDB = map[StatId]DerivedStat{
+		StatHitChance: {
+			Id:    StatHitChance,
+			Label: "Hit",
+			Formula: func(s Stats) float64 {
+				return 0.8 + (s.Value(StatSpeed)+s.Value(StatIntelligence))/2/255/2
+			},
+		},
+		StatCritChance: {
+			Id:    StatCritChance,
+			Label: "Critical",
+			Formula: func(s Stats) float64 {
+				return 0.05 + s.Value(StatSpeed)/500
+			},
+		},
+		StatEvasion: {
+			Id:    StatEvasion,
+			Label: "Evasion",
+			Formula: func(s Stats) float64 {
+				return 0.02 + s.Value(StatSpeed)/1000
+			},
+		},
+		StatMagicDefense: {
+			Id:    StatMagicDefense,
+			Label: "Magic Def",
+			Formula: func(s Stats) float64 {
+				return s.Value(StatResist) / 255
+			},
+		},
+	}
+}
+
+//StatCap past SoftCap only SoftRate of the excess counts,
+//the result is then kept within Min and Max
+type StatCap struct {
+	Min, Max          float64
+	SoftCap, SoftRate float64 //SoftCap 0 means none
+}
+
+//StatCaps stats missing here e.g. elements are not capped
+var StatCaps = map[StatId]StatCap{
+	StatHpMax:        {Min: 1, Max: 9999},
+	StatMpMax:        {Max: 999},
+	StatStrength:     {Max: 255, SoftCap: 150, SoftRate: 0.5},
+	StatSpeed:        {Max: 255, SoftCap: 150, SoftRate: 0.5},
+	StatIntelligence: {Max: 255, SoftCap: 150, SoftRate: 0.5},
+	StatAttack:       {Max: 999},
+	StatDefense:      {Max: 999},
+	StatMagic:        {Max: 999},
+	StatResist:       {Max: 255},
+	StatHitChance:    {Max: 0.99},
+	StatCritChance:   {Max: 0.5, SoftCap: 0.3, SoftRate: 0.5},
+	StatEvasion:      {Max: 0.5, SoftCap: 0.25, SoftRate: 0.5},
+	StatMagicDefense: {Max: 0.9, SoftCap: 0.6, SoftRate: 0.5},
+}
+
+func (c StatCap) Apply(value float64) float64 {
+	if c.SoftCap > 0 && value > c.SoftCap {
+		value = c.SoftCap + (value-c.SoftCap)*c.SoftRate
+	}
+	return math.Max(c.Min, math.Min(c.Max, value))
+}
+
+func capStat(id StatId, value float64) float64 {
+	if c, ok := StatCaps[id]; ok {
+		return c.Apply(value)
+	}
+	return value
+}
+
+//StatBreakdown where a stat value comes from, Buffs is everything
+//not from Base or Equipment e.g. combat buffs and skills
+type StatBreakdown struct {
+	Base, Equipment, Buffs, Total float64
+}
+
+//Breakdown of stat id, Equipment is what SourceEquipment modifiers add
+func (s Stats) Breakdown(id StatId) StatBreakdown {
+	base := s.withKinds().Value(id)
+	equipment := s.withKinds(SourceEquipment).Value(id)
+	total := s.Value(id)
+	return StatBreakdown{
+		Base:      base,
+		Equipment: equipment - base,
+		Buffs:     total - equipment,
+		Total:     total,
+	}
+}
+
+//withKinds copy of s keeping only modifiers from sources of the given kinds
+func (s Stats) withKinds(kinds ...SourceKind) Stats {
+	c := Stats{d: &statsData{base: s.d.base}}
+	for _, a := range s.d.applied {
+		for _, kind := range kinds {
+			if a.Source.Kind == kind {
+				c.d.applied = append(c.d.applied, a)
+			}
+		}
+	}
+	return c
+}
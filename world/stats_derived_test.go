@@ -0,0 +1,55 @@
+package world
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivedStats(t *testing.T) {
+	s := StatsCreate(BaseStats{Speed: 50, Resist: 51})
+	if got := s.Value(StatCritChance); math.Abs(got-0.15) > 1e-9 {
+		t.Errorf("CritChance = 0.05 + Speed/500, got %v", got)
+	}
+	if got := s.Value(StatMagicDefense); math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("MagicDefense = Resist/255, got %v", got)
+	}
+
+	s.AddModifier(1, Mod{Add: BaseStats{Speed: 50}})
+	if got := s.Value(StatCritChance); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("derived stats must follow modifiers, got %v", got)
+	}
+}
+
+func TestStatCaps(t *testing.T) {
+	s := StatsCreate(BaseStats{Speed: 200, Resist: 999, HpMax: 20000})
+	if got := s.Get("Speed"); got != 175 {
+		t.Errorf("half of Speed past 150 counts, got %v", got)
+	}
+	if got := s.Get("HpMax"); got != 9999 {
+		t.Errorf("HpMax hard cap, got %v", got)
+	}
+	if got := s.Value(StatMagicDefense); got != 0.8 {
+		t.Errorf("MagicDefense 1 softened to 0.8, got %v", got)
+	}
+	if got := s.Get("Fire"); got != 0 {
+		t.Errorf("uncapped stat, got %v", got)
+	}
+
+	s.Set("Speed", 1000)
+	if got := s.Get("Speed"); got != 255 {
+		t.Errorf("Speed hard cap, got %v", got)
+	}
+}
+
+func TestStatBreakdown(t *testing.T) {
+	s := StatsCreate(BaseStats{Strength: 10})
+	s.Apply(EquipSource("Weapon"), Modifier{UniqueId: 1, Mod: Mod{Add: BaseStats{Strength: 5}}})
+	s.AddModifier(1019, Mod{Mult: BaseStats{Strength: 1}}) //buff
+	b := s.Breakdown(StatStrength)
+	if b != (StatBreakdown{Base: 10, Equipment: 5, Buffs: 15, Total: 30}) {
+		t.Errorf("got %+v", b)
+	}
+	if !s.HasModifier(1) || !s.HasModifier(1019) {
+		t.Errorf("Breakdown must not change stats")
+	}
+}
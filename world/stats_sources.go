@@ -0,0 +1,184 @@
+package world
+
+//SourceKind what applied a modifier, see ModSource
+type SourceKind int
+
+const (
+	SourceNone      SourceKind = iota //Stats.AddModifier
+	SourceEquipment                   //Id is the equip slot e.g. "Accessory1"
+	SourceSkill                       //Id is the SkillNode id
+	SourceSpell                       //Id is the SpellsDB key
+	SourceItem                        //Id is the Item name e.g. "Power Drink"
+	SourceStatus                      //Id is the status e.g. StatusPoison
+)
+
+//ModSource where a modifier comes from, two copies of an accessory
+//are told apart by their slots
+type ModSource struct {
+	Kind SourceKind
+	Id   string
+}
+
+func EquipSource(slotId string) ModSource {
+	return ModSource{Kind: SourceEquipment, Id: slotId}
+}
+
+//StackPolicy what happens when a Modifier with the same UniqueId and SourceKind
+//is applied again
+type StackPolicy int
+
+const (
+	StackRefresh StackPolicy = iota //replaces the previous one, duration starts over
+	StackAdd                        //both count
+	StackHighest                    //both are kept, only the strongest counts for each stat
+)
+
+//AppliedMod a Modifier on Stats, see Stats.Modifiers and Stats.Affecting
+type AppliedMod struct {
+	Source    ModSource
+	Modifier  Modifier
+	TurnsLeft int     //0 if Modifier.Turns is 0
+	TimeLeft  float64 //0 if Modifier.Seconds is 0
+}
+
+type appliedMod struct {
+	AppliedMod
+	add, mult, final statValues
+}
+
+func (a AppliedMod) sameGroup(b AppliedMod) bool {
+	return a.Source.Kind == b.Source.Kind && a.Modifier.UniqueId == b.Modifier.UniqueId
+}
+
+//Apply modifier from source. Applied again by the same source it always
+//replaces the previous one, other sources follow modifier.Stack
+func (s *Stats) Apply(source ModSource, modifier Modifier) {
+	applied := AppliedMod{
+		Source:    source,
+		Modifier:  modifier,
+		TurnsLeft: modifier.Turns,
+		TimeLeft:  modifier.Seconds,
+	}
+	s.remove(func(a AppliedMod) bool {
+		if !a.sameGroup(applied) {
+			return false
+		}
+		return a.Source == source || modifier.Stack == StackRefresh
+	})
+
+	s.d.applied = append(s.d.applied, appliedMod{
+		AppliedMod: applied,
+		add:        modifier.Mod.Add.values(),
+		mult:       modifier.Mod.Mult.values(),
+		final:      modifier.Mod.Final.values(),
+	})
+	s.invalidate()
+}
+
+//RemoveSource every modifier applied by source e.g. an unequipped slot
+func (s *Stats) RemoveSource(source ModSource) []AppliedMod {
+	return s.remove(func(a AppliedMod) bool {
+		return a.Source == source
+	})
+}
+
+//RemoveKind every modifier applied by a source of kind e.g. SourceSkill on respec
+func (s *Stats) RemoveKind(kind SourceKind) []AppliedMod {
+	return s.remove(func(a AppliedMod) bool {
+		return a.Source.Kind == kind
+	})
+}
+
+//TurnPassed counts down modifiers with Turns, returns those that ran out
+func (s *Stats) TurnPassed() []AppliedMod {
+	for i := range s.d.applied {
+		if s.d.applied[i].Modifier.Turns > 0 {
+			s.d.applied[i].TurnsLeft--
+		}
+	}
+	return s.remove(func(a AppliedMod) bool {
+		return a.Modifier.Turns > 0 && a.TurnsLeft <= 0
+	})
+}
+
+//TimePassed counts down modifiers with Seconds, returns those that ran out
+func (s *Stats) TimePassed(dt float64) []AppliedMod {
+	for i := range s.d.applied {
+		if s.d.applied[i].Modifier.Seconds > 0 {
+			s.d.applied[i].TimeLeft -= dt
+		}
+	}
+	return s.remove(func(a AppliedMod) bool {
+		return a.Modifier.Seconds > 0 && a.TimeLeft <= 0
+	})
+}
+
+func (s *Stats) remove(match func(a AppliedMod) bool) (removed []AppliedMod) {
+	kept := s.d.applied[:0]
+	for _, a := range s.d.applied {
+		if match(a.AppliedMod) {
+			removed = append(removed, a.AppliedMod)
+		} else {
+			kept = append(kept, a)
+		}
+	}
+	s.d.applied = kept
+	if len(removed) > 0 {
+		s.invalidate()
+	}
+	return removed
+}
+
+//Modifiers everything applied to s, in the order it was applied
+func (s Stats) Modifiers() []AppliedMod {
+	list := make([]AppliedMod, len(s.d.applied))
+	for i, a := range s.d.applied {
+		list[i] = a.AppliedMod
+	}
+	return list
+}
+
+//Affecting modifiers that change stat id right now, StackHighest ones
+//outdone by a stronger one are left out. Derived stats only change
+//through the stats they are computed from
+func (s Stats) Affecting(id StatId) []AppliedMod {
+	var list []AppliedMod
+	if id.IsDerived() {
+		return list
+	}
+	for i, a := range s.d.applied {
+		if a.add[id] == 0 && a.mult[id] == 0 && a.final[id] == 0 {
+			continue
+		}
+		if s.d.counts(i, id) {
+			list = append(list, a.AppliedMod)
+		}
+	}
+	return list
+}
+
+//counts false if applied[i] is StackHighest and another one of its group
+//changes stat id more, the earlier one wins a tie
+func (d *statsData) counts(i int, id StatId) bool {
+	a := d.applied[i]
+	if a.Modifier.Stack != StackHighest {
+		return true
+	}
+	strength := d.strength(a, id)
+	for j, b := range d.applied {
+		if j == i || b.Modifier.Stack != StackHighest || !b.sameGroup(a.AppliedMod) {
+			continue
+		}
+		other := d.strength(b, id)
+		if other > strength || (other == strength && j < i) {
+			return false
+		}
+	}
+	return true
+}
+
+//strength stat id with only a applied
+func (d *statsData) strength(a appliedMod, id StatId) float64 {
+	total := d.base[id] + a.add[id]
+	return total * (1 + a.mult[id]) * (1 + a.final[id])
+}
@@ -0,0 +1,96 @@
+package world
+
+import "testing"
+
+func TestSameAccessoryInTwoSlots(t *testing.T) {
+	s := StatsCreate(BaseStats{Strength: 10})
+	ring := Item{Id: 3, Name: "Ring", Stats: Mod{Add: BaseStats{Strength: 5}}}
+	s.Apply(EquipSource("Accessory1"), ring.Modifier())
+	s.Apply(EquipSource("Accessory2"), ring.Modifier())
+	if got := s.Value(StatStrength); got != 20 {
+		t.Errorf("both rings must count, got %v", got)
+	}
+
+	s.RemoveSource(EquipSource("Accessory1"))
+	if got := s.Value(StatStrength); got != 15 {
+		t.Errorf("one ring left, got %v", got)
+	}
+	if len(s.Affecting(StatStrength)) != 1 {
+		t.Errorf("got %v", s.Affecting(StatStrength))
+	}
+}
+
+func TestStackPolicies(t *testing.T) {
+	spell := func(id string) ModSource { return ModSource{Kind: SourceSpell, Id: id} }
+	weak := Modifier{UniqueId: 7, Mod: Mod{Add: BaseStats{Strength: 2}}}
+	strong := Modifier{UniqueId: 7, Mod: Mod{Add: BaseStats{Strength: 6}}}
+
+	s := StatsCreate(BaseStats{Strength: 10})
+	s.Apply(spell("hero"), weak)
+	s.Apply(spell("mage"), strong)
+	if got := s.Value(StatStrength); got != 16 {
+		t.Errorf("StackRefresh replaces, got %v", got)
+	}
+
+	weak.Stack, strong.Stack = StackAdd, StackAdd
+	s = StatsCreate(BaseStats{Strength: 10})
+	s.Apply(spell("hero"), weak)
+	s.Apply(spell("mage"), strong)
+	s.Apply(spell("mage"), strong)
+	if got := s.Value(StatStrength); got != 18 {
+		t.Errorf("StackAdd stacks once per source, got %v", got)
+	}
+
+	weak.Stack, strong.Stack = StackHighest, StackHighest
+	s = StatsCreate(BaseStats{Strength: 10})
+	s.Apply(spell("mage"), strong)
+	s.Apply(spell("hero"), weak)
+	if got := s.Value(StatStrength); got != 16 {
+		t.Errorf("StackHighest keeps the strongest, got %v", got)
+	}
+	if a := s.Affecting(StatStrength); len(a) != 1 || a[0].Source != spell("mage") {
+		t.Errorf("weaker modifier is not affecting Strength, got %v", a)
+	}
+	s.RemoveSource(spell("mage"))
+	if got := s.Value(StatStrength); got != 12 {
+		t.Errorf("weaker one counts once the strongest is gone, got %v", got)
+	}
+}
+
+func TestModifierDurations(t *testing.T) {
+	s := StatsCreate(BaseStats{Speed: 10})
+	s.Apply(ModSource{Kind: SourceItem, Id: "Tonic"}, Modifier{Name: "Tonic", UniqueId: 1, Turns: 2, Mod: Mod{Add: BaseStats{Speed: 5}}})
+	s.Apply(ModSource{Kind: SourceSpell, Id: "haste"}, Modifier{Name: "Haste", UniqueId: 2, Seconds: 1.5, Mod: Mod{Add: BaseStats{Speed: 1}}})
+	s.Apply(EquipSource("Weapon"), Modifier{UniqueId: 3, Mod: Mod{Add: BaseStats{Speed: 2}}})
+
+	if expired := s.TurnPassed(); len(expired) != 0 || s.Value(StatSpeed) != 18 {
+		t.Errorf("one turn left, got %v %v", expired, s.Value(StatSpeed))
+	}
+	if expired := s.TurnPassed(); len(expired) != 1 || expired[0].Modifier.Name != "Tonic" {
+		t.Errorf("Tonic must run out, got %v", expired)
+	}
+	if expired := s.TimePassed(1); len(expired) != 0 || s.Value(StatSpeed) != 13 {
+		t.Errorf("Haste has 0.5s left, got %v %v", expired, s.Value(StatSpeed))
+	}
+	if expired := s.TimePassed(1); len(expired) != 1 || s.Value(StatSpeed) != 12 {
+		t.Errorf("Haste must run out, got %v %v", expired, s.Value(StatSpeed))
+	}
+	if len(s.Modifiers()) != 1 {
+		t.Errorf("equipment never runs out, got %v", s.Modifiers())
+	}
+}
+
+func TestModifierPhases(t *testing.T) {
+	s := StatsCreate(BaseStats{Attack: 10})
+	s.Apply(ModSource{Kind: SourceSpell, Id: "a"}, Modifier{UniqueId: 1, Mod: Mod{Mult: BaseStats{Attack: 0.5}, Final: BaseStats{Attack: 1}}})
+	s.Apply(ModSource{Kind: SourceSpell, Id: "b"}, Modifier{UniqueId: 2, Mod: Mod{Add: BaseStats{Attack: 10}, Mult: BaseStats{Attack: 0.5}, Final: BaseStats{Attack: 0.5}}})
+	//(10 + 10) * (1 + 0.5 + 0.5) * 2 * 1.5
+	if got := s.Value(StatAttack); got != 120 {
+		t.Errorf("got %v", got)
+	}
+
+	s.RemoveKind(SourceSpell)
+	if got := s.Value(StatAttack); got != 10 {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,146 @@
+package world
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fatih/structs"
+)
+
+//legacyGet the reflection based Stats.Get this package used before StatId,
+//kept to check behavior and to compare in benchmarks
+func legacyGet(base BaseStats, mods []Mod, id string) float64 {
+	total := structs.Map(base)[id].(float64)
+	multiplier := 0.0
+	for _, modifier := range mods {
+		total += reflect.ValueOf(structs.Map(modifier.Add)[id]).Interface().(float64)
+		multiplier += reflect.ValueOf(structs.Map(modifier.Mult)[id]).Interface().(float64)
+	}
+	return total + (total * multiplier)
+}
+
+var (
+	benchBase = BaseStats{HpNow: 40, HpMax: 40, MpNow: 8, MpMax: 8, Strength: 10, Speed: 12, Intelligence: 10}
+	benchMods = []Mod{
+		{Add: BaseStats{Attack: 5, Strength: 2}},
+		{Add: BaseStats{Defense: 3}, Mult: BaseStats{Strength: 0.5}},
+		{Add: BaseStats{Speed: 4, HpMax: 20}, Mult: BaseStats{Speed: 0.1}},
+	}
+)
+
+func benchStats() Stats {
+	s := StatsCreate(benchBase)
+	for i, m := range benchMods {
+		s.AddModifier(i+1, m)
+	}
+	return s
+}
+
+func TestStatIdsMatchBaseStats(t *testing.T) {
+	typ := reflect.TypeOf(BaseStats{})
+	for i := 0; i < typ.NumField(); i++ {
+		if got := StatId(i).String(); got != typ.Field(i).Name {
+			t.Errorf("StatId %d is %s, BaseStats field is %s", i, got, typ.Field(i).Name)
+		}
+	}
+	if StatHitChance != StatId(typ.NumField()) {
+		t.Errorf("derived stats must follow BaseStats fields")
+	}
+}
+
+func TestGetMatchesLegacy(t *testing.T) {
+	s := benchStats()
+	for name := range structs.Map(BaseStats{}) {
+		if got, want := s.Get(name), legacyGet(benchBase, benchMods, name); got != want {
+			t.Errorf("%s: got %v want %v", name, got, want)
+		}
+	}
+}
+
+func TestValueCacheInvalidation(t *testing.T) {
+	s := StatsCreate(BaseStats{Strength: 10})
+	copy_ := s
+	if s.Value(StatStrength) != 10 {
+		t.Fatalf("got %v", s.Value(StatStrength))
+	}
+
+	copy_.AddModifier(1, Mod{Add: BaseStats{Strength: 5}, Mult: BaseStats{Strength: 1}})
+	if s.Value(StatStrength) != 30 {
+		t.Errorf("copies share values and cache, got %v", s.Value(StatStrength))
+	}
+
+	s.Set("Strength", 20)
+	if s.Get("Strength") != 50 || s.GetBaseStat("Strength") != 20 {
+		t.Errorf("Set must invalidate cached totals, got %v", s.Get("Strength"))
+	}
+
+	s.RemoveModifier(1)
+	if s.Value(StatStrength) != 20 {
+		t.Errorf("RemoveModifier must invalidate cached totals, got %v", s.Value(StatStrength))
+	}
+}
+
+func TestParseStat(t *testing.T) {
+	if id, ok := ParseStat("CritChance"); !ok || id != StatCritChance {
+		t.Errorf("got %v %v", id, ok)
+	}
+	if _, ok := ParseStat("Luck"); ok {
+		t.Errorf("Luck is not a stat")
+	}
+}
+
+func TestGetUnknownStat(t *testing.T) {
+	s := StatsCreate(BaseStats{Strength: 10})
+	if got := s.Get("Luck"); got != 0 {
+		t.Errorf("unknown stats read as 0, got %v", got)
+	}
+	if _, ok := s.Lookup("Luck"); ok {
+		t.Errorf("Luck is not a stat")
+	}
+	if v, ok := s.Lookup("Strength"); !ok || v != 10 {
+		t.Errorf("got %v %v", v, ok)
+	}
+}
+
+func TestSetUnknownStat(t *testing.T) {
+	s := StatsCreate(BaseStats{Strength: 10})
+	if s.Set("Luck", 5) {
+		t.Errorf("Luck is not a stat")
+	}
+	if !s.Set("Strength", 12) || s.Get("Strength") != 12 {
+		t.Errorf("got %v", s.Get("Strength"))
+	}
+}
+
+func BenchmarkLegacyGet(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		legacyGet(benchBase, benchMods, "Strength")
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	s := benchStats()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Get("Strength")
+	}
+}
+
+func BenchmarkValue(b *testing.B) {
+	s := benchStats()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Value(StatStrength)
+	}
+}
+
+//BenchmarkValueUncached HpNow changes every hit in combat
+func BenchmarkValueUncached(b *testing.B) {
+	s := benchStats()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.SetValue(StatHpNow, float64(i%40))
+		s.Value(StatStrength)
+	}
+}
@@ -0,0 +1,22 @@
+package world
+
+//Status ailments an Actor can suffer from, cured by UseAction.Cures
+const (
+	StatusPoison  = "Poison"
+	StatusBlind   = "Blind"
+	StatusSilence = "Silence"
+)
+
+var AllStatuses = []string{
+	StatusPoison,
+	StatusBlind,
+	StatusSilence,
+}
+
+//StatusRegen is a positive status, not an ailment, so it is not in AllStatuses
+const StatusRegen = "Regen"
+
+//StatusPassives passives granted while an Actor has the status
+var StatusPassives = map[string][]string{
+	StatusRegen: {PassiveRegen},
+}
@@ -0,0 +1,81 @@
+package world
+
+const (
+	TechFireSword   = "FireSword"
+	TechThunderRaid = "ThunderRaid"
+	TechDeltaStorm  = "DeltaStorm"
+)
+
+//TechMember a party member taking part in a Tech and the ability they must know
+type TechMember struct {
+	ActorId string
+	Action  string //combat.ActionMagic or ActionSpecial
+	Id      string //SpellsDB or SpecialsDB key
+}
+
+//Tech dual or triple technique performed together by party members,
+//it takes every member's next turn and MP, see combat.AvailableTechs
+type Tech struct {
+	Id, Name   string
+	Members    []TechMember
+	MpCost     float64 //paid by every member
+	Element    string  //e.g. SpellFire, "" for none
+	Power      float64 //multiplies the members' combined attack
+	TimePoints float64
+	Target     ItemTarget
+}
+
+var TechsDB = map[string]Tech{
+	TechFireSword: {
+		Id:   TechFireSword,
+		Name: "Fire Sword",
+		Members: []TechMember{
+			{ActorId: "hero", Action: "Special", Id: SpecialSlash},
+			{ActorId: "mage", Action: "Magic", Id: SpellFire},
+		},
+		MpCost:     4,
+		Element:    SpellFire,
+		Power:      1.5,
+		TimePoints: 10,
+		Target: ItemTarget{
+			Selector:    WeakestEnemy,
+			SwitchSides: true,
+			Type:        CombatTargetTypeONE,
+		},
+	},
+	TechThunderRaid: {
+		Id:   TechThunderRaid,
+		Name: "Thunder Raid",
+		Members: []TechMember{
+			{ActorId: "thief", Action: "Special", Id: SpecialSteal},
+			{ActorId: "mage", Action: "Magic", Id: SpellBolt},
+		},
+		MpCost:     4,
+		Element:    SpellBolt,
+		Power:      1.5,
+		TimePoints: 10,
+		Target: ItemTarget{
+			Selector:    WeakestEnemy,
+			SwitchSides: true,
+			Type:        CombatTargetTypeONE,
+		},
+	},
+	TechDeltaStorm: {
+		Id:   TechDeltaStorm,
+		Name: "Delta Storm",
+		Members: []TechMember{
+			{ActorId: "hero", Action: "Special", Id: SpecialSlash},
+			{ActorId: "mage", Action: "Magic", Id: SpellBurn},
+			{ActorId: "thief", Action: "Special", Id: SpecialSteal},
+		},
+		MpCost:     8,
+		Element:    SpellFire,
+		Power:      1.2,
+		TimePoints: 20,
+		Target: ItemTarget{
+			Selector:    SideEnemy,
+			SwitchSides: false,
+			Type:        CombatTargetTypeSIDE,
+		},
+	},
+}
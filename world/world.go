@@ -7,19 +7,23 @@ import (
 
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/text"
-	log "github.com/sirupsen/logrus"
+	"github.com/steelx/go-rpg-cgm/utilz"
 	"golang.org/x/image/font/basicfont"
 )
 
 type World struct {
 	Time, Gold      float64
 	Items, KeyItems []ItemIndex
+	Storage         []ItemIndex //overflow items which dont fit into Items stacks
+	SortMode        SortMode
+	acquired        int //increments on every pickup, used by SortByRecent
 	//Party check world_extended.go
 	Icons Icons
 }
 
 type ItemIndex struct {
 	Id, Count int
+	Acquired  int //order in which the item was last picked up
 }
 
 func Create() *World {
@@ -28,6 +32,7 @@ func Create() *World {
 		Gold:     0,
 		Items:    make([]ItemIndex, 0),
 		KeyItems: make([]ItemIndex, 0),
+		Storage:  make([]ItemIndex, 0),
 		Icons:    IconsDB,
 	}
 
@@ -41,59 +46,56 @@ func Create() *World {
 }
 
 func (w *World) AddItem(itemId, count int) {
-	if _, ok := ItemsDB[itemId]; !ok {
-		log.Fatal(fmt.Sprintf("Item ID {%v} does not exists in DB", itemId))
+	limit := mustGetItem(itemId).StackLimit()
+	w.acquired++
+
+	index := indexOfItem(w.Items, itemId)
+	if index == -1 {
+		//Add new
+		w.Items = append(w.Items, ItemIndex{Id: itemId})
+		index = len(w.Items) - 1
 	}
 
-	for i := range w.Items {
-		//Does it already exist in World
-		if w.Items[i].Id == itemId {
-			w.Items[i].Count += count
-			return
-		}
-	}
+	//Does it already exist in World, top up the stack
+	stack := &w.Items[index]
+	stack.Acquired = w.acquired
+	room := utilz.MaxInt(0, limit-stack.Count)
+	added := utilz.MinInt(room, count)
+	stack.Count += added
 
-	//Add new
-	w.Items = append(w.Items, ItemIndex{
-		Id:    itemId,
-		Count: count,
-	})
+	//whatever doesn't fit in the stack goes to Storage
+	if overflow := count - added; overflow > 0 {
+		w.Storage = addToStack(w.Storage, itemId, overflow)
+	}
+	if stack.Count == 0 {
+		w.Items = removeItemAtIndex(w.Items, index)
+	}
 }
 
 func (w *World) RemoveItem(itemId, count int) {
-	if _, ok := ItemsDB[itemId]; !ok {
-		log.Fatal(fmt.Sprintf("Item ID {%v} does not exists in DB", itemId))
-	}
+	mustGetItem(itemId)
 
-	for i := len(w.Items) - 1; i >= 0; i-- {
-		//Does it already exist in World
-		if w.Items[i].Id == itemId {
-			w.Items[i].Count -= count
-		}
+	index := indexOfItem(w.Items, itemId)
+	if index == -1 {
+		return
+	}
 
-		if w.Items[i].Count <= 0 {
-			w.removeItemFromArray(i)
-			return
-		}
+	w.Items[index].Count -= count
+	if w.Items[index].Count <= 0 {
+		w.Items = removeItemAtIndex(w.Items, index)
 	}
 }
 
-func (w *World) removeItemFromArray(index int) {
-	if len(w.Items) == 1 {
-		w.Items = make([]ItemIndex, 0)
-		return
+//ItemCount returns how many of itemId are in the inventory (Storage excluded)
+func (w World) ItemCount(itemId int) int {
+	if index := indexOfItem(w.Items, itemId); index != -1 {
+		return w.Items[index].Count
 	}
-	w.Items[index], w.Items[0] = w.Items[0], w.Items[index]
-	w.Items = w.Items[1:]
+	return 0
 }
 
 func (w World) hasKeyItem(itemId int) bool {
-	for _, v := range w.KeyItems {
-		if v.Id == itemId {
-			return true
-		}
-	}
-	return false
+	return indexOfItem(w.KeyItems, itemId) != -1
 }
 
 func (w *World) AddKeyItem(itemId int) {
@@ -102,22 +104,17 @@ func (w *World) AddKeyItem(itemId int) {
 		return
 	}
 
-	w.KeyItems = append(w.KeyItems, ItemIndex{Id: itemId, Count: 1})
+	w.acquired++
+	w.KeyItems = append(w.KeyItems, ItemIndex{Id: itemId, Count: 1, Acquired: w.acquired})
 }
+
 func (w *World) RemoveKeyItem(itemId int) {
-	if !w.hasKeyItem(itemId) {
+	index := indexOfItem(w.KeyItems, itemId)
+	if index == -1 {
 		return
 	}
 
-	w.removeKeyItemFromArray(itemId)
-}
-func (w *World) removeKeyItemFromArray(index int) {
-	if len(w.KeyItems) == 1 {
-		w.Items = make([]ItemIndex, 0)
-		return
-	}
-	w.KeyItems[index], w.KeyItems[0] = w.KeyItems[0], w.KeyItems[index]
-	w.KeyItems = w.KeyItems[1 : len(w.KeyItems)-1]
+	w.KeyItems = removeItemAtIndex(w.KeyItems, index)
 }
 
 func (w *World) Update(dt float64) {